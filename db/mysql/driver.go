@@ -1,6 +1,7 @@
 package mysql
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
@@ -48,14 +49,39 @@ func (d *Driver) Init() error {
 
 // Tx 开启事务
 func (d *Driver) Tx() (*Executor, error) {
+	return d.TxContext(context.Background())
+}
+
+// TxContext 使用指定 context.Context 开启事务，context 取消后事务自动回滚
+func (d *Driver) TxContext(ctx context.Context) (*Executor, error) {
 
-	tx, err := d.Executor.getDb().Begin()
+	tx, err := d.Executor.getDb().BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	executor := new(Executor)
 	executor.init(ExecutorTypeTx, nil, tx)
+	executor.ctx = ctx
 
 	return executor, nil
 }
+
+// PingContext 检查数据库连接
+func (d *Driver) PingContext(ctx context.Context) error {
+	return d.Executor.getDb().PingContext(ctx)
+}
+
+// Stats 连接池统计信息
+func (d *Driver) Stats() sql.DBStats {
+	return d.Executor.getDb().Stats()
+}
+
+// Close 关闭连接池
+func (d *Driver) Close() error {
+	if d.Executor == nil || d.Executor.getDb() == nil {
+		return nil
+	}
+
+	return d.Executor.getDb().Close()
+}
@@ -1,6 +1,7 @@
 package mysql
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 
@@ -18,6 +19,7 @@ type Executor struct {
 	executorType ExecutorType
 	db           *sql.DB
 	tx           *sql.Tx
+	ctx          context.Context
 }
 
 // init 初始化
@@ -37,6 +39,22 @@ func (e *Executor) getTx() *sql.Tx {
 	return e.tx
 }
 
+// context 执行 SQL 时使用的 context.Context
+func (e *Executor) context() context.Context {
+	if e.ctx == nil {
+		return context.Background()
+	}
+	return e.ctx
+}
+
+// WithContext 返回使用指定 context.Context 执行 SQL 的执行器，context 取消或超时后中止执行
+func (e *Executor) WithContext(ctx context.Context) *Executor {
+	executor := new(Executor)
+	executor.init(e.executorType, e.db, e.tx)
+	executor.ctx = ctx
+	return executor
+}
+
 // GetTable 获取表记录
 func (e *Executor) GetTable(table string) *Table {
 	t := new(Table)
@@ -60,9 +78,9 @@ func (e *Executor) GetValue(sq string, args ...any) (string, error) {
 	var rows *sql.Rows
 	var err error
 	if e.executorType == ExecutorTypeDb {
-		rows, err = e.db.Query(sq, args...)
+		rows, err = e.db.QueryContext(e.context(), sq, args...)
 	} else {
-		rows, err = e.tx.Query(sq, args...)
+		rows, err = e.tx.QueryContext(e.context(), sq, args...)
 	}
 	if err != nil {
 		return "", err
@@ -82,9 +100,9 @@ func (e *Executor) GetValues(sq string, args ...any) ([]string, error) {
 	var rows *sql.Rows
 	var err error
 	if e.executorType == ExecutorTypeDb {
-		rows, err = e.db.Query(sq, args...)
+		rows, err = e.db.QueryContext(e.context(), sq, args...)
 	} else {
-		rows, err = e.tx.Query(sq, args...)
+		rows, err = e.tx.QueryContext(e.context(), sq, args...)
 	}
 	if err != nil {
 		return nil, err
@@ -107,9 +125,9 @@ func (e *Executor) GetMap(sq string, args ...any) (map[string]string, error) {
 	var rows *sql.Rows
 	var err error
 	if e.executorType == ExecutorTypeDb {
-		rows, err = e.db.Query(sq, args...)
+		rows, err = e.db.QueryContext(e.context(), sq, args...)
 	} else {
-		rows, err = e.tx.Query(sq, args...)
+		rows, err = e.tx.QueryContext(e.context(), sq, args...)
 	}
 	if err != nil {
 		return nil, err
@@ -153,9 +171,9 @@ func (e *Executor) GetMaps(sq string, args ...any) ([]map[string]string, error)
 	var rows *sql.Rows
 	var err error
 	if e.executorType == ExecutorTypeDb {
-		rows, err = e.db.Query(sq, args...)
+		rows, err = e.db.QueryContext(e.context(), sq, args...)
 	} else {
-		rows, err = e.tx.Query(sq, args...)
+		rows, err = e.tx.QueryContext(e.context(), sq, args...)
 	}
 	if err != nil {
 		return nil, err
@@ -191,18 +209,18 @@ func (e *Executor) GetMaps(sq string, args ...any) ([]map[string]string, error)
 // Query 查询，返回查询结果集，用于 select
 func (e *Executor) Query(sq string, args ...any) (*sql.Rows, error) {
 	if e.executorType == ExecutorTypeDb {
-		return e.db.Query(sq, args...)
+		return e.db.QueryContext(e.context(), sq, args...)
 	} else {
-		return e.tx.Query(sq, args...)
+		return e.tx.QueryContext(e.context(), sq, args...)
 	}
 }
 
 // Exec 执行，用于 insert / update / delete
 func (e *Executor) Exec(sq string, args ...any) (sql.Result, error) {
 	if e.executorType == ExecutorTypeDb {
-		return e.db.Exec(sq, args...)
+		return e.db.ExecContext(e.context(), sq, args...)
 	} else {
-		return e.tx.Exec(sq, args...)
+		return e.tx.ExecContext(e.context(), sq, args...)
 	}
 }
 
@@ -230,9 +248,9 @@ func (e *Executor) Insert(table string, data map[string]any) (sql.Result, error)
 	sq += ") VALUES (" + vs + ")"
 
 	if e.executorType == ExecutorTypeDb {
-		return e.db.Exec(sq, args...)
+		return e.db.ExecContext(e.context(), sq, args...)
 	} else {
-		return e.tx.Exec(sq, args...)
+		return e.tx.ExecContext(e.context(), sq, args...)
 	}
 }
 
@@ -285,9 +303,9 @@ func (e *Executor) Update(table string, data map[string]any, primaryKeys ...stri
 	}
 
 	if e.executorType == ExecutorTypeDb {
-		return e.db.Exec(sq, args...)
+		return e.db.ExecContext(e.context(), sq, args...)
 	} else {
-		return e.tx.Exec(sq, args...)
+		return e.tx.ExecContext(e.context(), sq, args...)
 	}
 }
 
@@ -310,9 +328,9 @@ func (e *Executor) Delete(table string, where map[string]any) (sql.Result, error
 	}
 
 	if e.executorType == ExecutorTypeDb {
-		return e.db.Exec(sq, args...)
+		return e.db.ExecContext(e.context(), sq, args...)
 	} else {
-		return e.tx.Exec(sq, args...)
+		return e.tx.ExecContext(e.context(), sq, args...)
 	}
 }
 
@@ -321,8 +339,8 @@ func (e *Executor) Truncate(table string) (sql.Result, error) {
 	sq := "TRUNCATE " + table
 
 	if e.executorType == ExecutorTypeDb {
-		return e.db.Exec(sq)
+		return e.db.ExecContext(e.context(), sq)
 	} else {
-		return e.tx.Exec(sq)
+		return e.tx.ExecContext(e.context(), sq)
 	}
 }
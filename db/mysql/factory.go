@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"errors"
+	"sync"
 	"time"
 
 	"github.com/go-ini/ini"
@@ -40,6 +41,9 @@ type Config struct {
 var configs map[string]*Config
 var drivers map[string]*Driver
 
+// drivers 的互斥锁
+var driversMu sync.Mutex
+
 // initConfig 初始化配置
 func initConfig() *Config {
 	return &Config{
@@ -164,6 +168,9 @@ func GetConfig(name string) (*Config, error) {
 
 // GetDb 获取数据库实例
 func GetDb(name string) (*Driver, error) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+
 	d, ok := drivers[name]
 	if ok {
 		return d, nil
@@ -188,3 +195,43 @@ func GetDb(name string) (*Driver, error) {
 
 	return nil, errors.New("mysql (" + name + ") not found")
 }
+
+// CloseDb 关闭指定数据库实例的连接池
+func CloseDb(name string) error {
+	driversMu.Lock()
+	d, ok := drivers[name]
+	if !ok {
+		driversMu.Unlock()
+		return nil
+	}
+
+	delete(drivers, name)
+	driversMu.Unlock()
+
+	return d.Close()
+}
+
+// CloseAll 关闭所有数据库实例的连接池
+func CloseAll() error {
+	var errs []error
+	for name := range GetDrivers() {
+		if err := CloseDb(name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// GetDrivers 获取已创建的实例，不会创建新的连接池
+func GetDrivers() map[string]*Driver {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+
+	d := make(map[string]*Driver, len(drivers))
+	for name, driver := range drivers {
+		d[name] = driver
+	}
+
+	return d
+}
@@ -5,6 +5,7 @@ go 1.21
 require (
 	github.com/go-ini/ini v1.67.0
 	github.com/go-sql-driver/mysql v1.7.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require github.com/stretchr/testify v1.9.0 // indirect
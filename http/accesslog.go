@@ -0,0 +1,134 @@
+package http
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-nt/nt/util/fs/file"
+)
+
+// SetAccessLogger 设置访问日志记录器，设置后每个请求记录一条访问日志
+func (server *Server) SetAccessLogger(logger *slog.Logger) {
+	server.accessLogger.Store(logger)
+}
+
+// initAccessLogger 按配置创建访问日志记录器
+func (server *Server) initAccessLogger() error {
+	if !server.config.accessLog || server.accessLogger.Load() != nil {
+		return nil
+	}
+
+	var w io.Writer = os.Stdout
+	if server.config.accessLogFile != "" {
+		rw, err := file.NewRotateWriter(server.config.accessLogFile, server.config.accessLogMaxSize*1024*1024, server.config.accessLogMaxBackups)
+		if err != nil {
+			return err
+		}
+		server.accessLogCloser = rw
+		w = rw
+	}
+
+	switch server.config.accessLogFormat {
+	case "json":
+		server.accessLogger.Store(slog.New(slog.NewJSONHandler(w, nil)))
+	case "combined":
+		server.accessLogger.Store(slog.New(&combinedHandler{w: w, mu: new(sync.Mutex)}))
+	default:
+		server.accessLogger.Store(slog.New(slog.NewTextHandler(w, nil)))
+	}
+
+	return nil
+}
+
+// closeAccessLogger 关闭按配置创建的访问日志文件，再次启动时重新创建
+// 通过 SetAccessLogger 设置的记录器不受影响
+func (server *Server) closeAccessLogger() error {
+	if server.accessLogCloser == nil {
+		return nil
+	}
+
+	server.accessLogger.Store(nil)
+	err := server.accessLogCloser.Close()
+	server.accessLogCloser = nil
+
+	return err
+}
+
+// logAccess 记录访问日志
+func (server *Server) logAccess(logger *slog.Logger, c *Context, start time.Time) {
+	r := c.Request.Request
+
+	logger.LogAttrs(r.Context(), slog.LevelInfo, "access",
+		slog.String("request_id", c.RequestId()),
+		slog.String("client_ip", c.Request.ClientIP()),
+		slog.String("method", r.Method),
+		slog.String("path", r.URL.RequestURI()),
+		slog.String("proto", r.Proto),
+		slog.String("handler", c.HandlerName()),
+		slog.Int("status", c.Response.StatusCode()),
+		slog.Int64("bytes", c.Response.Size()),
+		slog.Duration("latency", time.Since(start)),
+		slog.String("referer", r.Referer()),
+		slog.String("user_agent", r.UserAgent()),
+	)
+}
+
+// combinedHandler 以 Apache combined 格式输出访问日志的 slog.Handler
+type combinedHandler struct {
+	w  io.Writer
+	mu *sync.Mutex
+}
+
+// Enabled 实现 slog.Handler 接口
+func (h *combinedHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+// Handle 实现 slog.Handler 接口
+func (h *combinedHandler) Handle(_ context.Context, record slog.Record) error {
+	attrs := make(map[string]slog.Value)
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs[attr.Key] = attr.Value
+		return true
+	})
+
+	field := func(key string) string {
+		if value, ok := attrs[key]; ok {
+			if s := value.String(); s != "" {
+				return s
+			}
+		}
+		return "-"
+	}
+
+	bytes := "-"
+	if value, ok := attrs["bytes"]; ok && value.Kind() == slog.KindInt64 && value.Int64() > 0 {
+		bytes = strconv.FormatInt(value.Int64(), 10)
+	}
+
+	line := field("client_ip") + " - - [" + record.Time.Format("02/Jan/2006:15:04:05 -0700") + "] \"" +
+		field("method") + " " + field("path") + " " + field("proto") + "\" " +
+		field("status") + " " + bytes + " " +
+		strconv.Quote(field("referer")) + " " + strconv.Quote(field("user_agent")) + "\n"
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	_, err := io.WriteString(h.w, line)
+	return err
+}
+
+// WithAttrs 实现 slog.Handler 接口
+func (h *combinedHandler) WithAttrs([]slog.Attr) slog.Handler {
+	return h
+}
+
+// WithGroup 实现 slog.Handler 接口
+func (h *combinedHandler) WithGroup(string) slog.Handler {
+	return h
+}
@@ -0,0 +1,238 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-nt/nt/db/mysql"
+	ntHttp "github.com/go-nt/nt/http"
+	"github.com/go-nt/nt/redis"
+)
+
+// Config 管理端点配置
+type Config struct {
+	// 端点路径前缀，如 /admin，为空时挂载在根路径
+	Prefix string
+
+	// 就绪检查的 mysql 实例名称，为 nil 时检查所有已配置及已创建的实例，尚未创建的实例在检查时创建
+	Databases []string
+
+	// 就绪检查的 redis 实例名称，为 nil 时检查所有已配置及已创建的实例，尚未创建的实例在检查时创建
+	Redis []string
+
+	// 单项检查超时时间，0 时使用 3 秒
+	Timeout time.Duration
+}
+
+// Check 自定义就绪检查
+type Check func(ctx context.Context) error
+
+// Admin 健康检查、就绪检查及监控指标端点
+type Admin struct {
+	config  Config
+	metrics *metrics
+
+	mu     sync.RWMutex
+	checks map[string]Check
+}
+
+// New 创建管理端点
+func New(config ...Config) *Admin {
+	a := &Admin{
+		metrics: newMetrics(),
+		checks:  make(map[string]Check),
+	}
+	if len(config) > 0 {
+		a.config = config[0]
+	}
+	if a.config.Timeout <= 0 {
+		a.config.Timeout = 3 * time.Second
+	}
+
+	return a
+}
+
+// Install 在服务上启用指标采集并挂载 /healthz、/readyz、/metrics 端点
+func (a *Admin) Install(server *ntHttp.Server) error {
+	server.Use(a.Middleware())
+	return a.AddRoutes(server)
+}
+
+// AddRoutes 仅挂载端点，可用于单独的管理端口，指标采集中间件需在业务服务上通过 Middleware 启用
+func (a *Admin) AddRoutes(server *ntHttp.Server) error {
+	routes := map[string]ntHttp.HandlerFunc{
+		"/healthz": a.healthz,
+		"/readyz":  a.readyz,
+		"/metrics": a.serveMetrics,
+	}
+
+	for path, handler := range routes {
+		if err := server.AddRoute("GET", a.config.Prefix+path, handler); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddCheck 添加自定义就绪检查
+func (a *Admin) AddCheck(name string, check Check) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.checks[name] = check
+}
+
+// healthz 存活检查
+func (a *Admin) healthz(c *ntHttp.Context) {
+	c.Response.Header("Content-Type", "text/plain; charset=utf-8")
+	c.Response.Header("Cache-Control", "no-store")
+	c.Response.Write("ok")
+}
+
+// readyz 就绪检查，任一检查失败时返回 503
+func (a *Admin) readyz(c *ntHttp.Context) {
+	checks := a.allChecks()
+
+	names := make([]string, 0, len(checks))
+	for name := range checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	status := "ok"
+	details := make(map[string]string, len(names))
+	for _, name := range names {
+		ctx, cancel := context.WithTimeout(c.Context(), a.config.Timeout)
+		err := checks[name](ctx)
+		cancel()
+
+		if err != nil {
+			status = "error"
+			details[name] = err.Error()
+		} else {
+			details[name] = "ok"
+		}
+	}
+
+	content, _ := json.Marshal(map[string]any{
+		"status": status,
+		"checks": details,
+	})
+
+	c.Response.Header("Content-Type", "application/json; charset=utf-8")
+	c.Response.Header("Cache-Control", "no-store")
+	if status != "ok" {
+		c.Response.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_, _ = c.Response.ResponseWriter.Write(content)
+}
+
+// allChecks 汇总 mysql、redis 及自定义就绪检查
+func (a *Admin) allChecks() map[string]Check {
+	checks := make(map[string]Check)
+
+	for _, name := range a.databaseNames() {
+		name := name
+		checks["mysql:"+name] = func(ctx context.Context) error {
+			return runCheck(ctx, func() error {
+				db, err := mysql.GetDb(name)
+				if err != nil {
+					return err
+				}
+				return db.PingContext(ctx)
+			})
+		}
+	}
+
+	for _, name := range a.redisNames() {
+		name := name
+		checks["redis:"+name] = func(ctx context.Context) error {
+			return runCheck(ctx, func() error {
+				r, err := redis.GetRedis(name)
+				if err != nil {
+					return err
+				}
+				return r.Ping(ctx)
+			})
+		}
+	}
+
+	a.mu.RLock()
+	for name, check := range a.checks {
+		checks[name] = check
+	}
+	a.mu.RUnlock()
+
+	return checks
+}
+
+// runCheck 执行检查，超时后不再等待，创建连接池时的连接不受 ctx 控制
+func runCheck(ctx context.Context, check func() error) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- check()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// databaseNames 需检查的 mysql 实例名称
+func (a *Admin) databaseNames() []string {
+	if a.config.Databases != nil {
+		return a.config.Databases
+	}
+
+	var names []string
+	for name := range mysql.GetConfigs("") {
+		names = append(names, name)
+	}
+	for name := range mysql.GetDrivers() {
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// redisNames 需检查的 redis 实例名称
+func (a *Admin) redisNames() []string {
+	if a.config.Redis != nil {
+		return a.config.Redis
+	}
+
+	var names []string
+	for name := range redis.GetConfigs() {
+		names = append(names, name)
+	}
+	for name := range redis.GetDrivers() {
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// databases 已创建的 mysql 实例，用于输出连接池指标，不会为此创建连接池
+func (a *Admin) databases() map[string]*mysql.Driver {
+	drivers := mysql.GetDrivers()
+	if a.config.Databases == nil {
+		return drivers
+	}
+
+	selected := make(map[string]*mysql.Driver)
+	for _, name := range a.config.Databases {
+		if d, ok := drivers[name]; ok {
+			selected[name] = d
+		}
+	}
+
+	return selected
+}
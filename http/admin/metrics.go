@@ -0,0 +1,219 @@
+package admin
+
+import (
+	"bytes"
+	"database/sql"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	ntHttp "github.com/go-nt/nt/http"
+)
+
+// 请求耗时直方图分桶（秒）
+var latencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+type requestKey struct {
+	handler string
+	method  string
+	status  int
+}
+
+type dbStats struct {
+	sql.DBStats
+	name string
+}
+
+type histogram struct {
+	// 各分桶计数（非累计）
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+type metrics struct {
+	inFlight int64
+
+	mu        sync.Mutex
+	requests  map[requestKey]uint64
+	latencies map[string]*histogram
+}
+
+// newMetrics 创建指标采集器
+func newMetrics() *metrics {
+	return &metrics{
+		requests:  make(map[requestKey]uint64),
+		latencies: make(map[string]*histogram),
+	}
+}
+
+// observe 记录一次请求
+func (m *metrics) observe(handler string, method string, status int, latency time.Duration) {
+	seconds := latency.Seconds()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[requestKey{handler: handler, method: method, status: status}]++
+
+	h, ok := m.latencies[handler]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(latencyBuckets))}
+		m.latencies[handler] = h
+	}
+	for i, bucket := range latencyBuckets {
+		if seconds <= bucket {
+			h.counts[i]++
+			break
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// Middleware 指标采集中间件，记录请求数、耗时及处理中的请求数
+func (a *Admin) Middleware() ntHttp.Middleware {
+	return func(next ntHttp.Handler) ntHttp.Handler {
+		return ntHttp.HandlerFunc(func(c *ntHttp.Context) {
+			start := time.Now()
+			atomic.AddInt64(&a.metrics.inFlight, 1)
+
+			defer func() {
+				atomic.AddInt64(&a.metrics.inFlight, -1)
+
+				status := c.Response.StatusCode()
+				rec := recover()
+				if rec != nil {
+					status = 500
+				}
+
+				handler := c.HandlerName()
+				if handler == "" {
+					handler = "unmatched"
+				}
+				a.metrics.observe(handler, c.Request.Method(), status, time.Since(start))
+
+				if rec != nil {
+					panic(rec)
+				}
+			}()
+
+			next.OnRequest(c)
+		})
+	}
+}
+
+// serveMetrics 以 Prometheus 文本格式输出指标
+func (a *Admin) serveMetrics(c *ntHttp.Context) {
+	var buf bytes.Buffer
+
+	a.writeRequestMetrics(&buf)
+	a.writeDbMetrics(&buf)
+
+	c.Response.Header("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	c.Response.Header("Cache-Control", "no-store")
+	_, _ = c.Response.ResponseWriter.Write(buf.Bytes())
+}
+
+// writeRequestMetrics 输出请求相关指标
+func (a *Admin) writeRequestMetrics(buf *bytes.Buffer) {
+	m := a.metrics
+
+	buf.WriteString("# HELP go_nt_http_requests_in_flight Number of requests currently being served.\n")
+	buf.WriteString("# TYPE go_nt_http_requests_in_flight gauge\n")
+	buf.WriteString("go_nt_http_requests_in_flight " + strconv.FormatInt(atomic.LoadInt64(&m.inFlight), 10) + "\n")
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	keys := make([]requestKey, 0, len(m.requests))
+	for key := range m.requests {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].handler != keys[j].handler {
+			return keys[i].handler < keys[j].handler
+		}
+		if keys[i].method != keys[j].method {
+			return keys[i].method < keys[j].method
+		}
+		return keys[i].status < keys[j].status
+	})
+
+	buf.WriteString("# HELP go_nt_http_requests_total Total number of HTTP requests.\n")
+	buf.WriteString("# TYPE go_nt_http_requests_total counter\n")
+	for _, key := range keys {
+		buf.WriteString("go_nt_http_requests_total{handler=" + label(key.handler) + ",method=" + label(key.method) + ",status=" + label(strconv.Itoa(key.status)) + "} " + strconv.FormatUint(m.requests[key], 10) + "\n")
+	}
+
+	handlers := make([]string, 0, len(m.latencies))
+	for handler := range m.latencies {
+		handlers = append(handlers, handler)
+	}
+	sort.Strings(handlers)
+
+	buf.WriteString("# HELP go_nt_http_request_duration_seconds HTTP request latency in seconds.\n")
+	buf.WriteString("# TYPE go_nt_http_request_duration_seconds histogram\n")
+	for _, handler := range handlers {
+		h := m.latencies[handler]
+
+		var cumulative uint64
+		for i, bucket := range latencyBuckets {
+			cumulative += h.counts[i]
+			buf.WriteString("go_nt_http_request_duration_seconds_bucket{handler=" + label(handler) + ",le=" + label(strconv.FormatFloat(bucket, 'f', -1, 64)) + "} " + strconv.FormatUint(cumulative, 10) + "\n")
+		}
+		buf.WriteString("go_nt_http_request_duration_seconds_bucket{handler=" + label(handler) + ",le=\"+Inf\"} " + strconv.FormatUint(h.count, 10) + "\n")
+		buf.WriteString("go_nt_http_request_duration_seconds_sum{handler=" + label(handler) + "} " + strconv.FormatFloat(h.sum, 'f', -1, 64) + "\n")
+		buf.WriteString("go_nt_http_request_duration_seconds_count{handler=" + label(handler) + "} " + strconv.FormatUint(h.count, 10) + "\n")
+	}
+}
+
+// writeDbMetrics 输出数据库连接池指标
+func (a *Admin) writeDbMetrics(buf *bytes.Buffer) {
+	type dbMetric struct {
+		name   string
+		help   string
+		metric string
+		value  func(stats dbStats) string
+	}
+
+	var stats []dbStats
+	for name, db := range a.databases() {
+		stats = append(stats, dbStats{name: name, DBStats: db.Stats()})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].name < stats[j].name
+	})
+
+	if len(stats) == 0 {
+		return
+	}
+
+	for _, m := range []dbMetric{
+		{"go_nt_db_max_open_connections", "Maximum number of open connections to the database.", "gauge", func(s dbStats) string { return strconv.Itoa(s.MaxOpenConnections) }},
+		{"go_nt_db_open_connections", "Number of established connections both in use and idle.", "gauge", func(s dbStats) string { return strconv.Itoa(s.OpenConnections) }},
+		{"go_nt_db_in_use_connections", "Number of connections currently in use.", "gauge", func(s dbStats) string { return strconv.Itoa(s.InUse) }},
+		{"go_nt_db_idle_connections", "Number of idle connections.", "gauge", func(s dbStats) string { return strconv.Itoa(s.Idle) }},
+		{"go_nt_db_wait_count_total", "Total number of connections waited for.", "counter", func(s dbStats) string { return strconv.FormatInt(s.WaitCount, 10) }},
+		{"go_nt_db_wait_duration_seconds_total", "Total time blocked waiting for a new connection.", "counter", func(s dbStats) string { return strconv.FormatFloat(s.WaitDuration.Seconds(), 'f', -1, 64) }},
+		{"go_nt_db_max_idle_closed_total", "Total number of connections closed due to SetMaxIdleConns.", "counter", func(s dbStats) string { return strconv.FormatInt(s.MaxIdleClosed, 10) }},
+		{"go_nt_db_max_lifetime_closed_total", "Total number of connections closed due to SetConnMaxLifetime.", "counter", func(s dbStats) string { return strconv.FormatInt(s.MaxLifetimeClosed, 10) }},
+	} {
+		buf.WriteString("# HELP " + m.name + " " + m.help + "\n")
+		buf.WriteString("# TYPE " + m.name + " " + m.metric + "\n")
+		for _, s := range stats {
+			buf.WriteString(m.name + "{db=" + label(s.name) + "} " + m.value(s) + "\n")
+		}
+	}
+}
+
+// label 转义标签值
+func label(value string) string {
+	value = strings.ReplaceAll(value, "\\", "\\\\")
+	value = strings.ReplaceAll(value, "\"", "\\\"")
+	value = strings.ReplaceAll(value, "\n", "\\n")
+	return "\"" + value + "\""
+}
@@ -0,0 +1,16 @@
+package codec
+
+import "encoding/json"
+
+// Json JSON 编解码器
+type Json struct{}
+
+// Marshal 编码
+func (Json) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal 解码
+func (Json) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,410 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Msgpack MessagePack 编解码器
+// 结构体经 JSON 映射后编码，字段名及忽略规则与 JSON 一致，不支持扩展类型
+// 与 JSON 一致，bin 类型按 base64 映射：解码到 []byte 字段时为原始字节，解码到 string 或 any 时为 base64 文本，
+// []byte 编码为 base64 文本的 str 类型
+// 未使用第三方库：现有实现解码到 any 时不限制嵌套深度，恶意请求体可导致栈溢出使进程退出
+type Msgpack struct{}
+
+// msgpackMaxDepth 数组、map 的最大嵌套深度，与 encoding/json 一致
+const msgpackMaxDepth = 10000
+
+// Marshal 编码
+func (Msgpack) Marshal(v any) ([]byte, error) {
+	content, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+
+	var value any
+	if err = decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err = msgpackEncode(&buf, value); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Unmarshal 解码
+func (Msgpack) Unmarshal(data []byte, v any) error {
+	d := &msgpackDecoder{data: data}
+
+	value, err := d.decode()
+	if err != nil {
+		return err
+	}
+
+	if d.pos != len(d.data) {
+		return errMsgpackTrailing
+	}
+
+	content, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(content, v)
+}
+
+// msgpackEncode 编码 JSON 通用值
+func msgpackEncode(buf *bytes.Buffer, value any) error {
+	switch t := value.(type) {
+	case nil:
+		buf.WriteByte(0xc0)
+	case bool:
+		if t {
+			buf.WriteByte(0xc3)
+		} else {
+			buf.WriteByte(0xc2)
+		}
+	case json.Number:
+		if n, err := t.Int64(); err == nil {
+			msgpackEncodeInt(buf, n)
+			return nil
+		}
+
+		f, err := t.Float64()
+		if err != nil {
+			return err
+		}
+		buf.WriteByte(0xcb)
+		_ = binary.Write(buf, binary.BigEndian, math.Float64bits(f))
+	case string:
+		n := len(t)
+		switch {
+		case n < 32:
+			buf.WriteByte(0xa0 | byte(n))
+		case n <= math.MaxUint8:
+			buf.Write([]byte{0xd9, byte(n)})
+		case n <= math.MaxUint16:
+			buf.WriteByte(0xda)
+			_ = binary.Write(buf, binary.BigEndian, uint16(n))
+		default:
+			buf.WriteByte(0xdb)
+			_ = binary.Write(buf, binary.BigEndian, uint32(n))
+		}
+		buf.WriteString(t)
+	case []any:
+		n := len(t)
+		switch {
+		case n < 16:
+			buf.WriteByte(0x90 | byte(n))
+		case n <= math.MaxUint16:
+			buf.WriteByte(0xdc)
+			_ = binary.Write(buf, binary.BigEndian, uint16(n))
+		default:
+			buf.WriteByte(0xdd)
+			_ = binary.Write(buf, binary.BigEndian, uint32(n))
+		}
+		for _, item := range t {
+			if err := msgpackEncode(buf, item); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		n := len(t)
+		switch {
+		case n < 16:
+			buf.WriteByte(0x80 | byte(n))
+		case n <= math.MaxUint16:
+			buf.WriteByte(0xde)
+			_ = binary.Write(buf, binary.BigEndian, uint16(n))
+		default:
+			buf.WriteByte(0xdf)
+			_ = binary.Write(buf, binary.BigEndian, uint32(n))
+		}
+
+		keys := make([]string, 0, n)
+		for key := range t {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if err := msgpackEncode(buf, key); err != nil {
+				return err
+			}
+			if err := msgpackEncode(buf, t[key]); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("codec msgpack error: unsupported type %T", value)
+	}
+
+	return nil
+}
+
+// msgpackEncodeInt 以最短形式编码整数
+func msgpackEncodeInt(buf *bytes.Buffer, n int64) {
+	switch {
+	case n >= 0 && n <= math.MaxInt8:
+		buf.WriteByte(byte(n))
+	case n >= 0 && n <= math.MaxUint8:
+		buf.Write([]byte{0xcc, byte(n)})
+	case n >= 0 && n <= math.MaxUint16:
+		buf.WriteByte(0xcd)
+		_ = binary.Write(buf, binary.BigEndian, uint16(n))
+	case n >= 0 && n <= math.MaxUint32:
+		buf.WriteByte(0xce)
+		_ = binary.Write(buf, binary.BigEndian, uint32(n))
+	case n >= 0:
+		buf.WriteByte(0xcf)
+		_ = binary.Write(buf, binary.BigEndian, uint64(n))
+	case n >= -32:
+		buf.WriteByte(byte(n))
+	case n >= math.MinInt8:
+		buf.Write([]byte{0xd0, byte(n)})
+	case n >= math.MinInt16:
+		buf.WriteByte(0xd1)
+		_ = binary.Write(buf, binary.BigEndian, int16(n))
+	case n >= math.MinInt32:
+		buf.WriteByte(0xd2)
+		_ = binary.Write(buf, binary.BigEndian, int32(n))
+	default:
+		buf.WriteByte(0xd3)
+		_ = binary.Write(buf, binary.BigEndian, n)
+	}
+}
+
+var errMsgpackShort = errors.New("codec msgpack error: unexpected end of data")
+
+var errMsgpackDepth = errors.New("codec msgpack error: exceeded max depth")
+
+var errMsgpackTrailing = errors.New("codec msgpack error: unexpected data after top-level value")
+
+// msgpackDecoder 解码为 JSON 通用值
+type msgpackDecoder struct {
+	data  []byte
+	pos   int
+	depth int
+}
+
+// enter 进入一层数组或 map，超出最大嵌套深度时返回错误
+func (d *msgpackDecoder) enter() error {
+	d.depth++
+	if d.depth > msgpackMaxDepth {
+		return errMsgpackDepth
+	}
+
+	return nil
+}
+
+// next 读取 n 个字节
+func (d *msgpackDecoder) next(n int) ([]byte, error) {
+	if n < 0 || len(d.data)-d.pos < n {
+		return nil, errMsgpackShort
+	}
+
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+// length 读取 size 字节的长度
+func (d *msgpackDecoder) length(size int) (int, error) {
+	b, err := d.next(size)
+	if err != nil {
+		return 0, err
+	}
+
+	switch size {
+	case 1:
+		return int(b[0]), nil
+	case 2:
+		return int(binary.BigEndian.Uint16(b)), nil
+	default:
+		return int(binary.BigEndian.Uint32(b)), nil
+	}
+}
+
+// decode 解码一个值
+func (d *msgpackDecoder) decode() (any, error) {
+	head, err := d.next(1)
+	if err != nil {
+		return nil, err
+	}
+	c := head[0]
+
+	switch {
+	case c <= 0x7f:
+		return int64(c), nil
+	case c >= 0xe0:
+		return int64(int8(c)), nil
+	case c >= 0x80 && c <= 0x8f:
+		return d.decodeMap(int(c & 0x0f))
+	case c >= 0x90 && c <= 0x9f:
+		return d.decodeArray(int(c & 0x0f))
+	case c >= 0xa0 && c <= 0xbf:
+		return d.decodeString(int(c & 0x1f))
+	}
+
+	switch c {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xc5, 0xc6:
+		n, err := d.length(1 << (c - 0xc4))
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.next(n)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), b...), nil
+	case 0xca:
+		b, err := d.next(4)
+		if err != nil {
+			return nil, err
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), nil
+	case 0xcb:
+		b, err := d.next(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		b, err := d.next(1 << (c - 0xcc))
+		if err != nil {
+			return nil, err
+		}
+		switch c {
+		case 0xcc:
+			return uint64(b[0]), nil
+		case 0xcd:
+			return uint64(binary.BigEndian.Uint16(b)), nil
+		case 0xce:
+			return uint64(binary.BigEndian.Uint32(b)), nil
+		default:
+			return binary.BigEndian.Uint64(b), nil
+		}
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		b, err := d.next(1 << (c - 0xd0))
+		if err != nil {
+			return nil, err
+		}
+		switch c {
+		case 0xd0:
+			return int64(int8(b[0])), nil
+		case 0xd1:
+			return int64(int16(binary.BigEndian.Uint16(b))), nil
+		case 0xd2:
+			return int64(int32(binary.BigEndian.Uint32(b))), nil
+		default:
+			return int64(binary.BigEndian.Uint64(b)), nil
+		}
+	case 0xd9, 0xda, 0xdb:
+		n, err := d.length(1 << (c - 0xd9))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeString(n)
+	case 0xdc, 0xdd:
+		n, err := d.length(2 << (c - 0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeArray(n)
+	case 0xde, 0xdf:
+		n, err := d.length(2 << (c - 0xde))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeMap(n)
+	}
+
+	return nil, fmt.Errorf("codec msgpack error: unsupported type 0x%02x", c)
+}
+
+// decodeString 解码字符串
+func (d *msgpackDecoder) decodeString(n int) (any, error) {
+	b, err := d.next(n)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(b), nil
+}
+
+// decodeArray 解码数组
+func (d *msgpackDecoder) decodeArray(n int) (any, error) {
+	// 每个元素至少 1 字节，防止恶意长度导致过大分配
+	if n > len(d.data)-d.pos {
+		return nil, errMsgpackShort
+	}
+
+	if err := d.enter(); err != nil {
+		return nil, err
+	}
+	defer func() { d.depth-- }()
+
+	items := make([]any, 0, n)
+	for i := 0; i < n; i++ {
+		item, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// decodeMap 解码 map，非字符串的键转为字符串
+func (d *msgpackDecoder) decodeMap(n int) (any, error) {
+	if n*2 > len(d.data)-d.pos {
+		return nil, errMsgpackShort
+	}
+
+	if err := d.enter(); err != nil {
+		return nil, err
+	}
+	defer func() { d.depth-- }()
+
+	m := make(map[string]any, n)
+	for i := 0; i < n; i++ {
+		key, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := key.(type) {
+		case string:
+			m[t] = value
+		case []byte:
+			m[string(t)] = value
+		default:
+			m[fmt.Sprint(t)] = value
+		}
+	}
+
+	return m, nil
+}
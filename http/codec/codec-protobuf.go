@@ -0,0 +1,33 @@
+package codec
+
+import "errors"
+
+// ProtoMessage 可自行编解码的 protobuf 消息，如 gogo/protobuf 生成的类型
+// 使用 google.golang.org/protobuf 时可包装 proto.Marshal / proto.Unmarshal 实现
+type ProtoMessage interface {
+	Marshal() ([]byte, error)
+	Unmarshal(data []byte) error
+}
+
+// Protobuf protobuf 编解码器，v 须实现 ProtoMessage 接口
+type Protobuf struct{}
+
+// Marshal 编码
+func (Protobuf) Marshal(v any) ([]byte, error) {
+	message, ok := v.(ProtoMessage)
+	if !ok {
+		return nil, errors.New("codec protobuf error: value does not implement ProtoMessage")
+	}
+
+	return message.Marshal()
+}
+
+// Unmarshal 解码
+func (Protobuf) Unmarshal(data []byte, v any) error {
+	message, ok := v.(ProtoMessage)
+	if !ok {
+		return errors.New("codec protobuf error: value does not implement ProtoMessage")
+	}
+
+	return message.Unmarshal(data)
+}
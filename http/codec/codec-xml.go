@@ -0,0 +1,21 @@
+package codec
+
+import "encoding/xml"
+
+// Xml XML 编解码器
+type Xml struct{}
+
+// Marshal 编码，输出包含 XML 声明
+func (Xml) Marshal(v any) ([]byte, error) {
+	content, err := xml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), content...), nil
+}
+
+// Unmarshal 解码
+func (Xml) Unmarshal(data []byte, v any) error {
+	return xml.Unmarshal(data, v)
+}
@@ -0,0 +1,17 @@
+package codec
+
+import "gopkg.in/yaml.v3"
+
+// Yaml YAML 编解码器
+// 基于 gopkg.in/yaml.v3，其解析器自带嵌套深度及别名展开限制
+type Yaml struct{}
+
+// Marshal 编码
+func (Yaml) Marshal(v any) ([]byte, error) {
+	return yaml.Marshal(v)
+}
+
+// Unmarshal 解码
+func (Yaml) Unmarshal(data []byte, v any) error {
+	return yaml.Unmarshal(data, v)
+}
@@ -0,0 +1,159 @@
+package codec
+
+import (
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Codec 编解码器
+type Codec interface {
+	// Marshal 编码
+	Marshal(v any) ([]byte, error)
+
+	// Unmarshal 解码，v 须为指针
+	Unmarshal(data []byte, v any) error
+}
+
+// 默认 MIME 类型，Accept 为空或不匹配时使用
+const DefaultMimeType = "application/json"
+
+var (
+	mu sync.RWMutex
+
+	// MIME 类型 -> 编解码器
+	codecs = make(map[string]Codec)
+
+	// 注册顺序，通配符匹配时按此顺序选择
+	mimeTypes []string
+)
+
+func init() {
+	Register("application/json", Json{})
+	Register("text/json", Json{})
+	Register("application/xml", Xml{})
+	Register("text/xml", Xml{})
+	Register("application/yaml", Yaml{})
+	Register("application/x-yaml", Yaml{})
+	Register("text/yaml", Yaml{})
+	Register("application/msgpack", Msgpack{})
+	Register("application/x-msgpack", Msgpack{})
+	Register("application/vnd.msgpack", Msgpack{})
+	Register("application/x-protobuf", Protobuf{})
+	Register("application/protobuf", Protobuf{})
+}
+
+// Register 注册编解码器，已存在时替换
+func Register(mimeType string, codec Codec) {
+	mimeType = strings.ToLower(strings.TrimSpace(mimeType))
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, ok := codecs[mimeType]; !ok {
+		mimeTypes = append(mimeTypes, mimeType)
+	}
+	codecs[mimeType] = codec
+}
+
+// Get 按 MIME 类型获取编解码器，支持带参数的 Content-Type，如 application/json; charset=utf-8
+// 未注册的 +json / +xml 后缀类型按 JSON / XML 处理
+func Get(contentType string) (Codec, bool) {
+	mimeType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, false
+	}
+
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if codec, ok := codecs[mimeType]; ok {
+		return codec, true
+	}
+
+	switch {
+	case strings.HasSuffix(mimeType, "+json"):
+		codec, ok := codecs["application/json"]
+		return codec, ok
+	case strings.HasSuffix(mimeType, "+xml"):
+		codec, ok := codecs["application/xml"]
+		return codec, ok
+	}
+
+	return nil, false
+}
+
+// Negotiate 按 Accept 头选择编解码器，返回 MIME 类型
+// 支持 q 权重及 type/* 、*/* 通配符，Accept 为空时使用 DefaultMimeType，无匹配时返回 false
+func Negotiate(accept string) (string, Codec, bool) {
+	if strings.TrimSpace(accept) == "" {
+		codec, ok := Get(DefaultMimeType)
+		return DefaultMimeType, codec, ok
+	}
+
+	mu.RLock()
+	defer mu.RUnlock()
+
+	for _, mimeType := range parseAccept(accept) {
+		if codec, ok := codecs[mimeType]; ok {
+			return mimeType, codec, true
+		}
+
+		if mimeType == "*/*" {
+			return DefaultMimeType, codecs[DefaultMimeType], codecs[DefaultMimeType] != nil
+		}
+
+		if prefix, ok := strings.CutSuffix(mimeType, "/*"); ok {
+			if strings.HasPrefix(DefaultMimeType, prefix+"/") {
+				return DefaultMimeType, codecs[DefaultMimeType], codecs[DefaultMimeType] != nil
+			}
+			for _, registered := range mimeTypes {
+				if strings.HasPrefix(registered, prefix+"/") {
+					return registered, codecs[registered], true
+				}
+			}
+		}
+	}
+
+	return "", nil, false
+}
+
+// parseAccept 解析 Accept 头，按 q 权重从高到低排列，q=0 的类型忽略
+func parseAccept(accept string) []string {
+	type item struct {
+		mimeType string
+		q        float64
+	}
+
+	var items []item
+	for _, part := range strings.Split(accept, ",") {
+		mimeType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(value, 64); err != nil {
+				continue
+			}
+		}
+
+		if q > 0 {
+			items = append(items, item{mimeType: mimeType, q: q})
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].q > items[j].q
+	})
+
+	mimeTypes := make([]string, 0, len(items))
+	for _, item := range items {
+		mimeTypes = append(mimeTypes, item.mimeType)
+	}
+
+	return mimeTypes
+}
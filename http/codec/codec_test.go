@@ -0,0 +1,110 @@
+package codec
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMsgpackRoundTrip(t *testing.T) {
+	type item struct {
+		Name  string   `json:"name"`
+		N     int      `json:"n"`
+		F     float64  `json:"f"`
+		Tags  []string `json:"tags"`
+		Empty *int     `json:"empty"`
+	}
+
+	in := item{Name: "a", N: -300, F: 1.5, Tags: []string{"x", "y"}}
+	content, err := Msgpack{}.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var out item
+	if err = (Msgpack{}).Unmarshal(content, &out); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if out.Name != in.Name || out.N != in.N || out.F != in.F || len(out.Tags) != 2 || out.Empty != nil {
+		t.Errorf("Unmarshal() = %+v, want %+v", out, in)
+	}
+}
+
+func TestMsgpackMaxDepth(t *testing.T) {
+	// 每个 0x91 为只含一个元素的数组，嵌套深度等于字节数
+	data := bytes.Repeat([]byte{0x91}, 10<<20)
+
+	var v any
+	if err := (Msgpack{}).Unmarshal(data, &v); err != errMsgpackDepth {
+		t.Fatalf("Unmarshal() error = %v, want %v", err, errMsgpackDepth)
+	}
+
+	nested := append(bytes.Repeat([]byte{0x91}, 100), 0x01)
+	if err := (Msgpack{}).Unmarshal(nested, &v); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+}
+
+func TestMsgpackTruncated(t *testing.T) {
+	var v any
+	for _, data := range [][]byte{{0xdc, 0xff, 0xff}, {0xdb, 0xff, 0xff, 0xff, 0xff}, {0xcb, 0x00}} {
+		if err := (Msgpack{}).Unmarshal(data, &v); err == nil {
+			t.Errorf("Unmarshal(% x) error = nil, want error", data)
+		}
+	}
+}
+
+func TestMsgpackTrailingData(t *testing.T) {
+	var v any
+	if err := (Msgpack{}).Unmarshal([]byte{0x01, 0x02}, &v); err != errMsgpackTrailing {
+		t.Fatalf("Unmarshal() error = %v, want %v", err, errMsgpackTrailing)
+	}
+}
+
+func TestMsgpackBin(t *testing.T) {
+	// {"data": bin8(0x00 0xff)}
+	data := []byte{0x81, 0xa4, 'd', 'a', 't', 'a', 0xc4, 0x02, 0x00, 0xff}
+
+	var raw struct {
+		Data []byte `json:"data"`
+	}
+	if err := (Msgpack{}).Unmarshal(data, &raw); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !bytes.Equal(raw.Data, []byte{0x00, 0xff}) {
+		t.Errorf("Unmarshal() []byte = % x, want 00 ff", raw.Data)
+	}
+
+	var text struct {
+		Data string `json:"data"`
+	}
+	if err := (Msgpack{}).Unmarshal(data, &text); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if text.Data != "AP8=" {
+		t.Errorf("Unmarshal() string = %q, want base64 %q", text.Data, "AP8=")
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   string
+		ok     bool
+	}{
+		{"", DefaultMimeType, true},
+		{"*/*", DefaultMimeType, true},
+		{"application/xml", "application/xml", true},
+		{"text/html;q=0.5, application/x-yaml", "application/x-yaml", true},
+		{"application/msgpack;q=0.1, application/xml;q=0.9", "application/xml", true},
+		{"application/*", DefaultMimeType, true},
+		{"image/png", "", false},
+	}
+
+	for _, tt := range tests {
+		got, _, ok := Negotiate(tt.accept)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Negotiate(%q) = %q, %v, want %q, %v", tt.accept, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// CompressConfig 响应压缩配置
+type CompressConfig struct {
+	// 压缩级别，0 时使用默认级别
+	Level int
+
+	// 响应体达到该字节数才压缩，0 时使用 1024
+	MinSize int
+
+	// 不压缩的内容类型前缀，为空时使用默认列表（图片、音视频、压缩包、SSE 等）
+	ExcludedContentTypes []string
+}
+
+// 默认不压缩的内容类型前缀
+var defaultExcludedContentTypes = []string{
+	"image/",
+	"video/",
+	"audio/",
+	"font/woff",
+	"application/zip",
+	"application/gzip",
+	"application/x-gzip",
+	"application/x-bzip2",
+	"application/x-7z-compressed",
+	"application/x-rar-compressed",
+	"application/octet-stream",
+	"application/pdf",
+	"text/event-stream",
+}
+
+// Compress 响应压缩中间件，根据 Accept-Encoding 使用 gzip 或 deflate 压缩响应
+func Compress(config ...CompressConfig) Middleware {
+	var cfg CompressConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+	if cfg.Level == 0 {
+		cfg.Level = flate.DefaultCompression
+	}
+	if cfg.MinSize <= 0 {
+		cfg.MinSize = 1024
+	}
+	if cfg.ExcludedContentTypes == nil {
+		cfg.ExcludedContentTypes = defaultExcludedContentTypes
+	}
+
+	return func(next Handler) Handler {
+		return HandlerFunc(func(c *Context) {
+			// 追加而非覆盖，保留跨域等中间件已设置的 Vary
+			c.Response.ResponseWriter.Header().Add("Vary", "Accept-Encoding")
+
+			acceptEncoding := c.Request.Header("Accept-Encoding", "")
+			encoding := ""
+			if acceptsEncoding(acceptEncoding, "gzip") {
+				encoding = "gzip"
+			} else if acceptsEncoding(acceptEncoding, "deflate") {
+				encoding = "deflate"
+			}
+
+			if encoding == "" || c.Request.IsHead() {
+				next.OnRequest(c)
+				return
+			}
+
+			rw := c.Response.ResponseWriter
+			cw := &compressWriter{
+				ResponseWriter: rw,
+				config:         &cfg,
+				encoding:       encoding,
+			}
+			c.Response.ResponseWriter = cw
+
+			defer func() {
+				c.Response.ResponseWriter = rw
+				_ = cw.close()
+			}()
+
+			next.OnRequest(c)
+		})
+	}
+}
+
+// compressWriter 缓冲响应开头部分，达到阈值后决定是否压缩
+type compressWriter struct {
+	http.ResponseWriter
+
+	config   *CompressConfig
+	encoding string
+
+	// 待输出的状态码
+	status int
+
+	// 是否已决定压缩方式
+	decided bool
+
+	// 决定前缓冲的内容
+	buf bytes.Buffer
+
+	// 压缩写入器，为 nil 时不压缩
+	compressor io.WriteCloser
+}
+
+// WriteHeader 暂存状态码，待决定压缩方式后输出
+func (w *compressWriter) WriteHeader(code int) {
+	if w.decided {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+
+	// 1xx 信息响应直接输出
+	if code < 200 {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+
+	if w.status == 0 {
+		w.status = code
+	}
+}
+
+// Write 写入内容
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if !w.decided {
+		w.buf.Write(b)
+		if w.buf.Len() < w.config.MinSize {
+			return len(b), nil
+		}
+
+		if err := w.decide(); err != nil {
+			return 0, err
+		}
+		return len(b), nil
+	}
+
+	if w.compressor != nil {
+		return w.compressor.Write(b)
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush 实现 http.Flusher 接口，流式输出时立即决定压缩方式
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		_ = w.decide()
+	}
+
+	if w.compressor != nil {
+		switch t := w.compressor.(type) {
+		case *gzip.Writer:
+			_ = t.Flush()
+		case *flate.Writer:
+			_ = t.Flush()
+		}
+	}
+
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack 实现 http.Hijacker 接口
+func (w *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if hijacker, ok := w.ResponseWriter.(http.Hijacker); ok {
+		return hijacker.Hijack()
+	}
+	return nil, nil, errors.New("response writer does not implement http.Hijacker")
+}
+
+// Unwrap 获取原始 http.ResponseWriter，供 http.ResponseController 使用
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Written 是否已写入状态码或内容，含尚未输出的缓冲内容，供 response.Driver 判断响应是否已开始
+func (w *compressWriter) Written() bool {
+	return w.decided || w.status != 0 || w.buf.Len() > 0
+}
+
+// decide 根据已缓冲内容及响应头决定是否压缩，并输出状态码及缓冲内容
+func (w *compressWriter) decide() error {
+	w.decided = true
+
+	header := w.ResponseWriter.Header()
+	if header.Get("Content-Type") == "" && w.buf.Len() > 0 {
+		header.Set("Content-Type", http.DetectContentType(w.buf.Bytes()))
+	}
+
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	if w.shouldCompress() {
+		header.Set("Content-Encoding", w.encoding)
+		header.Del("Content-Length")
+
+		var err error
+		if w.encoding == "gzip" {
+			w.compressor, err = gzip.NewWriterLevel(w.ResponseWriter, w.config.Level)
+		} else {
+			w.compressor, err = flate.NewWriter(w.ResponseWriter, w.config.Level)
+		}
+		if err != nil {
+			w.compressor = nil
+			header.Del("Content-Encoding")
+		}
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+
+	if w.buf.Len() == 0 {
+		return nil
+	}
+
+	var err error
+	if w.compressor != nil {
+		_, err = w.compressor.Write(w.buf.Bytes())
+	} else {
+		_, err = w.ResponseWriter.Write(w.buf.Bytes())
+	}
+	w.buf.Reset()
+
+	return err
+}
+
+// shouldCompress 是否压缩当前响应
+func (w *compressWriter) shouldCompress() bool {
+	if w.buf.Len() < w.config.MinSize {
+		return false
+	}
+
+	if w.status < 200 || w.status == http.StatusNoContent || w.status == http.StatusNotModified || w.status == http.StatusPartialContent {
+		return false
+	}
+
+	header := w.ResponseWriter.Header()
+	if header.Get("Content-Encoding") != "" || header.Get("Content-Range") != "" {
+		return false
+	}
+
+	contentType := strings.ToLower(header.Get("Content-Type"))
+	for _, excluded := range w.config.ExcludedContentTypes {
+		if strings.HasPrefix(contentType, excluded) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// close 输出剩余内容并结束压缩
+func (w *compressWriter) close() error {
+	if !w.decided {
+		// 未写入任何内容且未设置状态码时交由后续处理（如错误处理器）输出
+		if w.status == 0 && w.buf.Len() == 0 {
+			return nil
+		}
+		if err := w.decide(); err != nil {
+			return err
+		}
+	}
+
+	if w.compressor != nil {
+		return w.compressor.Close()
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package http
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCompressKeepsVary(t *testing.T) {
+	server := &Server{}
+	if err := server.SetConfig(map[string]any{"cors_allow_origins": "https://app.example.com"}); err != nil {
+		t.Fatalf("SetConfig() error = %v", err)
+	}
+	server.Use(Compress())
+	server.AddHandler("items", HandlerFunc(func(c *Context) {
+		c.Response.Write("ok")
+	}))
+
+	r := httptest.NewRequest("GET", "/items", nil)
+	r.Header.Set("Origin", "https://app.example.com")
+	r.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+
+	server.ServeHTTP(w, r)
+
+	vary := strings.Join(w.Header().Values("Vary"), ", ")
+	if !strings.Contains(vary, "Origin") || !strings.Contains(vary, "Accept-Encoding") {
+		t.Errorf("Vary = %q, want Origin and Accept-Encoding", vary)
+	}
+}
+
+func TestCompressPartialThenError(t *testing.T) {
+	server := &Server{}
+	server.Use(Compress())
+	server.AddHandler("items", HandlerFuncE(func(c *Context) error {
+		c.Response.Write("partial")
+		return errors.New("failed")
+	}))
+
+	r := httptest.NewRequest("GET", "/items", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+
+	server.ServeHTTP(w, r)
+
+	if got := w.Body.String(); got != "partial" {
+		t.Errorf("body = %q, want %q", got, "partial")
+	}
+}
@@ -1,14 +1,33 @@
 package http
 
 import (
+	"context"
+	"net/http"
+
 	"github.com/go-nt/nt/http/request"
 	"github.com/go-nt/nt/http/response"
-	"net/http"
 )
 
+type contextKey string
+
+// requestIdKey context.Context 中存放请求 ID 的 key
+const requestIdKey contextKey = "requestId"
+
 type Context struct {
 	Request  *request.Driver
 	Response *response.Driver
+
+	// 错误处理器
+	errorHandler ErrorHandler
+
+	// 匹配的处理器名称或路由
+	handlerName string
+
+	// 请求 ID
+	requestId string
+
+	// 请求内共享的数据，供中间件与处理器传递数据
+	values map[string]any
 }
 
 // Init 初始化
@@ -19,7 +38,76 @@ func (c *Context) Init(r *http.Request, w http.ResponseWriter) {
 
 	res := new(response.Driver)
 	res.Init(w)
+	res.SetRequest(r)
 
 	c.Request = req
 	c.Response = res
 }
+
+// HandlerName 匹配的处理器名称，匹配路由时为 "方法 路由规则"
+func (c *Context) HandlerName() string {
+	return c.handlerName
+}
+
+// Context 请求的 context.Context，客户端断开或服务关闭时取消，可传递给数据库、redis 调用
+func (c *Context) Context() context.Context {
+	return c.Request.Request.Context()
+}
+
+// SetContext 替换请求的 context.Context，如设置超时时间
+func (c *Context) SetContext(ctx context.Context) {
+	c.Request.Request = c.Request.Request.WithContext(ctx)
+	c.Response.SetRequest(c.Request.Request)
+}
+
+// RequestId 请求 ID
+func (c *Context) RequestId() string {
+	return c.requestId
+}
+
+// setRequestId 设置请求 ID，同时写入 context.Context 及响应头
+func (c *Context) setRequestId(header string, requestId string) {
+	c.requestId = requestId
+	c.SetContext(context.WithValue(c.Context(), requestIdKey, requestId))
+	c.Response.Header(header, requestId)
+}
+
+// RequestIdFromContext 从 context.Context 中获取请求 ID
+func RequestIdFromContext(ctx context.Context) string {
+	requestId, _ := ctx.Value(requestIdKey).(string)
+	return requestId
+}
+
+// Set 设置请求内共享的数据
+func (c *Context) Set(name string, value any) {
+	if c.values == nil {
+		c.values = make(map[string]any)
+	}
+	c.values[name] = value
+}
+
+// Get 获取请求内共享的数据
+func (c *Context) Get(name string) any {
+	value, _ := c.values[name]
+	return value
+}
+
+// Has 是否已设置指定名称的共享数据
+func (c *Context) Has(name string) bool {
+	_, exists := c.values[name]
+	return exists
+}
+
+// Error 将错误交由错误处理器输出，错误可为 StatusError 以指定状态码
+func (c *Context) Error(err error) {
+	if err == nil {
+		return
+	}
+
+	if c.errorHandler != nil {
+		c.errorHandler(c, err)
+		return
+	}
+
+	DefaultErrorHandler(c, err)
+}
@@ -0,0 +1,181 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CorsConfig 跨域资源共享配置
+type CorsConfig struct {
+	// 允许的来源，* 表示任意来源，支持通配符，如 https://*.example.com
+	AllowOrigins []string
+
+	// 允许的请求方法，为空时使用 GET, POST, PUT, PATCH, DELETE, HEAD
+	AllowMethods []string
+
+	// 允许的请求头，为空时使用预检请求中的 Access-Control-Request-Headers
+	AllowHeaders []string
+
+	// 允许客户端读取的响应头
+	ExposeHeaders []string
+
+	// 是否允许携带 cookie 等凭证，不能与任意来源 * 同时使用
+	AllowCredentials bool
+
+	// 预检结果缓存时间，0-不设置
+	MaxAge time.Duration
+}
+
+// 默认允许的请求方法
+var defaultCorsAllowMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD"}
+
+// Cors 跨域中间件，可用于单个处理器或路由，预检请求直接返回 204
+// 用于路由时，预检请求经过 Access-Control-Request-Method 对应路由的中间件，Cors 须在鉴权等中间件之前
+// 来源为 * 时不输出 Access-Control-Allow-Credentials，即使设置了 AllowCredentials
+func Cors(config CorsConfig) Middleware {
+	return func(next Handler) Handler {
+		return HandlerFunc(func(c *Context) {
+			if config.handle(c) {
+				return
+			}
+			next.OnRequest(c)
+		})
+	}
+}
+
+// validate 校验配置，任意来源 * 与允许凭证同时使用时任何网站均可读取带凭证的响应
+func (config *CorsConfig) validate() error {
+	if !config.AllowCredentials {
+		return nil
+	}
+
+	for _, allowed := range config.AllowOrigins {
+		if allowed == "*" {
+			return errors.New("http server config parameter(cors_allow_credentials) can not be used with cors_allow_origins(*)")
+		}
+	}
+
+	return nil
+}
+
+// enabled 是否启用
+func (config *CorsConfig) enabled() bool {
+	return len(config.AllowOrigins) > 0
+}
+
+// handle 输出跨域响应头，为预检请求时直接响应并返回 true
+func (config *CorsConfig) handle(c *Context) bool {
+	origin := c.Request.Header("Origin", "")
+	if origin == "" {
+		return false
+	}
+
+	header := c.Response.ResponseWriter.Header()
+	preflight := isPreflight(c)
+
+	allowOrigin, ok := config.allowOrigin(origin)
+	if allowOrigin != "*" {
+		header.Add("Vary", "Origin")
+	}
+
+	if !ok {
+		if preflight {
+			c.Response.WriteHeader(http.StatusNoContent)
+			return true
+		}
+		return false
+	}
+
+	header.Set("Access-Control-Allow-Origin", allowOrigin)
+	if config.AllowCredentials && allowOrigin != "*" {
+		header.Set("Access-Control-Allow-Credentials", "true")
+	}
+
+	if !preflight {
+		if len(config.ExposeHeaders) > 0 {
+			header.Set("Access-Control-Expose-Headers", strings.Join(config.ExposeHeaders, ", "))
+		}
+		return false
+	}
+
+	header.Add("Vary", "Access-Control-Request-Method")
+	header.Add("Vary", "Access-Control-Request-Headers")
+
+	allowMethods := config.AllowMethods
+	if len(allowMethods) == 0 {
+		allowMethods = defaultCorsAllowMethods
+	}
+	header.Set("Access-Control-Allow-Methods", strings.Join(allowMethods, ", "))
+
+	if len(config.AllowHeaders) > 0 {
+		header.Set("Access-Control-Allow-Headers", strings.Join(config.AllowHeaders, ", "))
+	} else if requestHeaders := c.Request.Header("Access-Control-Request-Headers", ""); requestHeaders != "" {
+		header.Set("Access-Control-Allow-Headers", requestHeaders)
+	}
+
+	if config.MaxAge > 0 {
+		header.Set("Access-Control-Max-Age", strconv.Itoa(int(config.MaxAge.Seconds())))
+	}
+
+	c.Response.WriteHeader(http.StatusNoContent)
+	return true
+}
+
+// isPreflight 是否为跨域预检请求
+func isPreflight(c *Context) bool {
+	return c.Request.IsOptions() && c.Request.Header("Origin", "") != "" && c.Request.Header("Access-Control-Request-Method", "") != ""
+}
+
+// allowOrigin 匹配来源，返回 Access-Control-Allow-Origin 的值
+func (config *CorsConfig) allowOrigin(origin string) (string, bool) {
+	for _, allowed := range config.AllowOrigins {
+		// 任意来源不回写具体来源，浏览器不会向 * 暴露带凭证的响应
+		if allowed == "*" {
+			return "*", true
+		}
+
+		if matchOrigin(allowed, origin) {
+			return origin, true
+		}
+	}
+
+	return "", false
+}
+
+// matchOrigin 来源匹配，支持一个 * 通配符
+func matchOrigin(pattern string, origin string) bool {
+	if !strings.Contains(pattern, "*") {
+		return strings.EqualFold(pattern, origin)
+	}
+
+	prefix, suffix, _ := strings.Cut(strings.ToLower(pattern), "*")
+	origin = strings.ToLower(origin)
+
+	return len(origin) >= len(prefix)+len(suffix) && strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix)
+}
+
+// parseList 解析列表配置，支持字符串切片及逗号分隔的字符串
+func parseList(value any) ([]string, bool) {
+	var items []string
+	switch t := value.(type) {
+	case []string:
+		items = t
+	case string:
+		items = strings.Split(t, ",")
+	default:
+		return nil, false
+	}
+
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			list = append(list, item)
+		}
+	}
+
+	return list, true
+}
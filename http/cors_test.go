@@ -0,0 +1,100 @@
+package http
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMatchOrigin(t *testing.T) {
+	tests := []struct {
+		pattern string
+		origin  string
+		want    bool
+	}{
+		{"https://example.com", "https://example.com", true},
+		{"https://example.com", "HTTPS://EXAMPLE.COM", true},
+		{"https://example.com", "https://example.com.evil.com", false},
+		{"https://*.example.com", "https://api.example.com", true},
+		{"https://*.example.com", "https://a.b.example.com", true},
+		{"https://*.example.com", "https://example.com", false},
+		{"https://*.example.com", "https://evilexample.com", false},
+		{"https://*.example.com", "http://api.example.com", false},
+		{"https://*.example.com", "https://api.example.com.evil.com", false},
+		{"http://localhost:*", "http://localhost:8080", true},
+	}
+
+	for _, tt := range tests {
+		if got := matchOrigin(tt.pattern, tt.origin); got != tt.want {
+			t.Errorf("matchOrigin(%q, %q) = %v, want %v", tt.pattern, tt.origin, got, tt.want)
+		}
+	}
+}
+
+func TestCorsWildcardWithCredentials(t *testing.T) {
+	config := CorsConfig{AllowOrigins: []string{"*"}, AllowCredentials: true}
+	if err := config.validate(); err == nil {
+		t.Fatal("validate() = nil, want error for * with credentials")
+	}
+
+	server := &Server{}
+	if err := server.SetConfig(map[string]any{"cors_allow_origins": "*", "cors_allow_credentials": true}); err == nil {
+		t.Fatal("SetConfig() = nil, want error for * with credentials")
+	}
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Origin", "https://evil.com")
+	w := httptest.NewRecorder()
+
+	c := &Context{}
+	c.Init(r, w)
+	config.handle(c)
+
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("Access-Control-Allow-Origin = %q, want *", got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "" {
+		t.Errorf("Access-Control-Allow-Credentials = %q, want empty", got)
+	}
+}
+
+func TestCorsRoutePreflight(t *testing.T) {
+	server := &Server{}
+	handled := false
+	handler := HandlerFunc(func(c *Context) { handled = true })
+
+	if err := server.AddRoute("POST", "/api/items", handler, Cors(CorsConfig{AllowOrigins: []string{"https://app.example.com"}})); err != nil {
+		t.Fatalf("AddRoute() error = %v", err)
+	}
+	if err := server.AddRoute("POST", "/api/orders", handler); err != nil {
+		t.Fatalf("AddRoute() error = %v", err)
+	}
+
+	tests := []struct {
+		path       string
+		wantStatus int
+		wantOrigin string
+	}{
+		{"/api/items", 204, "https://app.example.com"},
+		{"/api/orders", 405, ""},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest("OPTIONS", tt.path, nil)
+		r.Header.Set("Origin", "https://app.example.com")
+		r.Header.Set("Access-Control-Request-Method", "POST")
+		w := httptest.NewRecorder()
+
+		server.ServeHTTP(w, r)
+
+		if w.Code != tt.wantStatus {
+			t.Errorf("OPTIONS %s status = %d, want %d", tt.path, w.Code, tt.wantStatus)
+		}
+		if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+			t.Errorf("OPTIONS %s Access-Control-Allow-Origin = %q, want %q", tt.path, got, tt.wantOrigin)
+		}
+	}
+
+	if handled {
+		t.Error("route handler ran for a preflight request")
+	}
+}
@@ -0,0 +1,126 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"html/template"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-nt/nt/http/request"
+)
+
+// ErrorHandler 错误处理器，负责将错误输出到客户端
+type ErrorHandler func(c *Context, err error)
+
+// StatusError 携带 HTTP 状态码的错误
+type StatusError struct {
+	// HTTP 状态码
+	Code int
+
+	// 返回给客户端的错误信息
+	Message string
+
+	// 原始错误，不返回给客户端
+	Err error
+}
+
+// NewStatusError 创建携带 HTTP 状态码的错误，message 为空时使用状态码对应的默认文本
+func NewStatusError(code int, message string, err ...error) *StatusError {
+	if message == "" {
+		message = http.StatusText(code)
+	}
+
+	e := &StatusError{
+		Code:    code,
+		Message: message,
+	}
+	if len(err) > 0 {
+		e.Err = err[0]
+	}
+
+	return e
+}
+
+// Error 实现 error 接口
+func (e *StatusError) Error() string {
+	if e.Err != nil {
+		return strconv.Itoa(e.Code) + " " + e.Message + ": " + e.Err.Error()
+	}
+
+	return strconv.Itoa(e.Code) + " " + e.Message
+}
+
+// Unwrap 获取原始错误
+func (e *StatusError) Unwrap() error {
+	return e.Err
+}
+
+var errorTemplate = template.Must(template.New("error").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{.Code}} {{.Message}}</title></head>
+<body><h1>{{.Code}} {{.Message}}</h1></body>
+</html>
+`))
+
+// DefaultErrorHandler 默认错误处理器，根据 Accept 头输出 JSON 或 HTML
+// 非 StatusError 类型的错误均按 500 处理，且不向客户端暴露错误详情，5xx 错误的原始错误记录到日志
+// 响应已开始输出时仅记录日志
+// 校验错误（request.ValidationError）按 422 输出字段错误列表
+func DefaultErrorHandler(c *Context, err error) {
+	var validationError *request.ValidationError
+	if errors.As(err, &validationError) {
+		if !c.Response.Written() {
+			ValidationErrorResponse(c, validationError)
+		}
+		return
+	}
+
+	var statusError *StatusError
+	if !errors.As(err, &statusError) {
+		statusError = NewStatusError(http.StatusInternalServerError, "", err)
+	}
+
+	if statusError.Code >= http.StatusInternalServerError && statusError.Err != nil {
+		log.Printf("go-nt http server error: %s %s: %v", c.Request.Method(), c.Request.Path(), statusError.Err)
+	}
+
+	if c.Response.Written() {
+		return
+	}
+
+	accept := c.Request.Header("Accept", "")
+	if strings.Contains(accept, "application/json") || (c.Request.IsAjax() && !strings.Contains(accept, "text/html")) {
+		content, _ := json.Marshal(map[string]any{
+			"code":    statusError.Code,
+			"message": statusError.Message,
+		})
+
+		c.Response.Header("Content-Type", "application/json; charset=utf-8")
+		c.Response.Header("X-Content-Type-Options", "nosniff")
+		c.Response.WriteHeader(statusError.Code)
+		_, _ = c.Response.ResponseWriter.Write(content)
+		return
+	}
+
+	c.Response.Header("Content-Type", "text/html; charset=utf-8")
+	c.Response.Header("X-Content-Type-Options", "nosniff")
+	c.Response.WriteHeader(statusError.Code)
+	_ = errorTemplate.Execute(c.Response.ResponseWriter, statusError)
+}
+
+// ValidationErrorResponse 以 422 JSON 输出校验错误，包含所有未通过校验的字段
+func ValidationErrorResponse(c *Context, err *request.ValidationError) {
+	content, _ := json.Marshal(map[string]any{
+		"code":    http.StatusUnprocessableEntity,
+		"message": http.StatusText(http.StatusUnprocessableEntity),
+		"errors":  err.Errors,
+	})
+
+	c.Response.Header("Content-Type", "application/json; charset=utf-8")
+	c.Response.Header("X-Content-Type-Options", "nosniff")
+	c.Response.WriteHeader(http.StatusUnprocessableEntity)
+	_, _ = c.Response.ResponseWriter.Write(content)
+}
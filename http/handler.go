@@ -3,3 +3,21 @@ package http
 type Handler interface {
 	OnRequest(*Context)
 }
+
+// HandlerFunc 函数形式的处理器
+type HandlerFunc func(*Context)
+
+// OnRequest 处理请求
+func (f HandlerFunc) OnRequest(c *Context) {
+	f(c)
+}
+
+// HandlerFuncE 返回错误的函数形式处理器，返回的错误交由服务的错误处理器输出
+type HandlerFuncE func(*Context) error
+
+// OnRequest 处理请求
+func (f HandlerFuncE) OnRequest(c *Context) {
+	if err := f(c); err != nil {
+		c.Error(err)
+	}
+}
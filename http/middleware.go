@@ -0,0 +1,15 @@
+package http
+
+// Middleware 中间件，包装下一个处理器，不调用 next.OnRequest 即可中止后续处理
+type Middleware func(next Handler) Handler
+
+// Chain 使用中间件包装处理器，第一个中间件位于最外层
+func Chain(handler Handler, middlewares ...Middleware) Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		if middlewares[i] != nil {
+			handler = middlewares[i](handler)
+		}
+	}
+
+	return handler
+}
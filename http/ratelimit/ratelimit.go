@@ -0,0 +1,98 @@
+package ratelimit
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	ntHttp "github.com/go-nt/nt/http"
+)
+
+// KeyFunc 获取限流的 key，返回空字符串时不限流
+type KeyFunc func(c *ntHttp.Context) string
+
+// Config 限流配置
+type Config struct {
+	// 时间窗口内允许的请求数
+	Limit int
+
+	// 时间窗口
+	Window time.Duration
+
+	// 限流 key，为空时按客户端 IP 限流
+	KeyFunc KeyFunc
+
+	// 存储，为空时使用内存存储
+	Store Store
+
+	// key 前缀，多个限流共用同一存储时用于区分
+	Prefix string
+}
+
+// New 创建限流中间件，超出限制时返回 429
+func New(config Config) ntHttp.Middleware {
+	if config.Limit <= 0 {
+		config.Limit = 60
+	}
+	if config.Window <= 0 {
+		config.Window = time.Minute
+	}
+	if config.KeyFunc == nil {
+		config.KeyFunc = KeyByIp
+	}
+	if config.Store == nil {
+		config.Store = NewMemoryStore()
+	}
+
+	return func(next ntHttp.Handler) ntHttp.Handler {
+		return ntHttp.HandlerFunc(func(c *ntHttp.Context) {
+			key := config.KeyFunc(c)
+			if key == "" {
+				next.OnRequest(c)
+				return
+			}
+
+			result, err := config.Store.Take(c.Context(), config.Prefix+key, config.Limit, config.Window)
+			if err != nil {
+				// 存储不可用时放行
+				log.Printf("go-nt http ratelimit store error: %v", err)
+				next.OnRequest(c)
+				return
+			}
+
+			c.Response.Header("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+			c.Response.Header("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			c.Response.Header("X-RateLimit-Reset", strconv.Itoa(seconds(result.Reset)))
+
+			if !result.Allowed {
+				c.Response.Header("Retry-After", strconv.Itoa(seconds(result.RetryAfter)))
+				c.Error(ntHttp.NewStatusError(http.StatusTooManyRequests, ""))
+				return
+			}
+
+			next.OnRequest(c)
+		})
+	}
+}
+
+// KeyByIp 按客户端 IP 限流，经可信代理转发时取转发头中的客户端 IP
+func KeyByIp(c *ntHttp.Context) string {
+	return "ip:" + c.Request.ClientIP()
+}
+
+// KeyBySession 按 session id 限流，cookieName 为 session 配置的名称，如 SSID
+// 未携带 session id 时按客户端 IP 限流
+func KeyBySession(cookieName string) KeyFunc {
+	return func(c *ntHttp.Context) string {
+		if id := c.Request.Cookie(cookieName, ""); id != "" {
+			return "session:" + id
+		}
+		return KeyByIp(c)
+	}
+}
+
+// seconds 向上取整为秒
+func seconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
+}
@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryCounter struct {
+	// 当前窗口序号
+	index int64
+
+	// 当前窗口计数
+	cur int
+
+	// 上一窗口计数
+	prev int
+}
+
+// MemoryStore 内存存储，仅适用于单实例部署
+type MemoryStore struct {
+	mu       sync.Mutex
+	counters map[string]*memoryCounter
+	takes    int
+}
+
+// NewMemoryStore 创建内存存储
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		counters: make(map[string]*memoryCounter),
+	}
+}
+
+// Take 在时间窗口内为 key 消耗一次请求额度
+func (s *MemoryStore) Take(_ context.Context, key string, limit int, window time.Duration) (*Result, error) {
+	now := time.Now()
+	index := now.UnixNano() / int64(window)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counter, ok := s.counters[key]
+	if !ok {
+		counter = &memoryCounter{index: index}
+		s.counters[key] = counter
+	}
+
+	switch {
+	case counter.index == index-1:
+		counter.prev = counter.cur
+		counter.cur = 0
+		counter.index = index
+	case counter.index < index-1:
+		counter.prev = 0
+		counter.cur = 0
+		counter.index = index
+	}
+
+	result, allowed := slidingWindow(now, window, limit, counter.prev, counter.cur)
+	if allowed {
+		counter.cur++
+	}
+
+	// 定期清理过期的计数
+	s.takes++
+	if s.takes >= 10000 {
+		s.takes = 0
+		for k, c := range s.counters {
+			if c.index < index-1 {
+				delete(s.counters, k)
+			}
+		}
+	}
+
+	return result, nil
+}
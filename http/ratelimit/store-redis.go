@@ -0,0 +1,68 @@
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-nt/nt/redis"
+	goRedis "github.com/go-redis/redis/v8"
+)
+
+// 原子地读取上一窗口及当前窗口计数，允许时当前窗口计数加一
+var redisTakeScript = goRedis.NewScript(`
+local cur = tonumber(redis.call('GET', KEYS[1]) or '0')
+local prev = tonumber(redis.call('GET', KEYS[2]) or '0')
+if prev * tonumber(ARGV[1]) + cur + 1 > tonumber(ARGV[2]) then
+	return {0, cur, prev}
+end
+cur = redis.call('INCR', KEYS[1])
+redis.call('PEXPIRE', KEYS[1], ARGV[3])
+return {1, cur - 1, prev}
+`)
+
+// RedisStore redis 存储，适用于多实例部署
+type RedisStore struct {
+	redis *redis.Driver
+}
+
+// NewRedisStore 使用指定名称的 redis 实例创建存储
+func NewRedisStore(name string) (*RedisStore, error) {
+	d, err := redis.GetRedis(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RedisStore{
+		redis: d,
+	}, nil
+}
+
+// Take 在时间窗口内为 key 消耗一次请求额度
+func (s *RedisStore) Take(ctx context.Context, key string, limit int, window time.Duration) (*Result, error) {
+	now := time.Now()
+	index := now.UnixNano() / int64(window)
+	elapsed := time.Duration(now.UnixNano() % int64(window))
+	weight := 1 - float64(elapsed)/float64(window)
+
+	keys := []string{
+		"ratelimit:" + key + ":" + strconv.FormatInt(index, 10),
+		"ratelimit:" + key + ":" + strconv.FormatInt(index-1, 10),
+	}
+	args := []any{
+		strconv.FormatFloat(weight, 'f', -1, 64),
+		limit,
+		(2 * window).Milliseconds(),
+	}
+
+	values, err := redisTakeScript.Run(ctx, s.redis.GetClient(), keys, args...).Int64Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	// 按脚本读取到的计数计算剩余额度
+	result, _ := slidingWindow(now, window, limit, int(values[2]), int(values[1]))
+	result.Allowed = values[0] == 1
+
+	return result, nil
+}
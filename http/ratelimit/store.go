@@ -0,0 +1,77 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Store 限流计数存储
+type Store interface {
+
+	// Take 在时间窗口内为 key 消耗一次请求额度
+	Take(ctx context.Context, key string, limit int, window time.Duration) (*Result, error)
+}
+
+// Result 限流结果
+type Result struct {
+	// 是否允许本次请求
+	Allowed bool
+
+	// 时间窗口内允许的请求数
+	Limit int
+
+	// 剩余可用的请求数
+	Remaining int
+
+	// 被拒绝时建议的重试等待时间
+	RetryAfter time.Duration
+
+	// 当前时间窗口结束的剩余时间
+	Reset time.Duration
+}
+
+// slidingWindow 滑动窗口计数：按上一窗口计数的剩余占比加上当前窗口计数估算窗口内的请求数
+// 返回本次请求是否允许
+func slidingWindow(now time.Time, window time.Duration, limit int, prev int, cur int) (*Result, bool) {
+	elapsed := time.Duration(now.UnixNano() % int64(window))
+	weight := 1 - float64(elapsed)/float64(window)
+	estimated := float64(prev)*weight + float64(cur)
+
+	result := &Result{
+		Limit: limit,
+		Reset: window - elapsed,
+	}
+
+	if estimated+1 > float64(limit) {
+		result.Allowed = false
+		result.Remaining = 0
+
+		if cur+1 > limit || prev == 0 {
+			// 当前窗口已满，需等待进入下一窗口，且当前窗口计数作为上一窗口衰减到足以容纳本次请求
+			result.RetryAfter = window - elapsed
+			if cur > 0 && limit > 0 {
+				need := 1 - float64(limit-1)/float64(cur)
+				if need > 0 {
+					result.RetryAfter += time.Duration(need * float64(window))
+				}
+			}
+		} else {
+			// 等待上一窗口的占比衰减到足以容纳本次请求
+			need := 1 - float64(limit-cur-1)/float64(prev)
+			result.RetryAfter = time.Duration(need*float64(window)) - elapsed
+		}
+		if result.RetryAfter < time.Second {
+			result.RetryAfter = time.Second
+		}
+
+		return result, false
+	}
+
+	result.Allowed = true
+	result.Remaining = int(float64(limit) - estimated - 1)
+	if result.Remaining < 0 {
+		result.Remaining = 0
+	}
+
+	return result, true
+}
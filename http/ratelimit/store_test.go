@@ -0,0 +1,50 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSlidingWindowRetryAfter(t *testing.T) {
+	window := time.Minute
+	start := time.Unix(0, 0)
+
+	tests := []struct {
+		name    string
+		limit   int
+		prev    int
+		cur     int
+		elapsed time.Duration
+	}{
+		{"current window full", 10, 0, 10, 30 * time.Second},
+		{"current window full with prev", 10, 10, 10, 50 * time.Second},
+		{"current window full early", 10, 10, 10, time.Second},
+		{"prev decaying", 10, 10, 5, 10 * time.Second},
+		{"limit one", 1, 0, 1, 59 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := start.Add(tt.elapsed)
+			result, ok := slidingWindow(now, window, tt.limit, tt.prev, tt.cur)
+			if ok {
+				t.Fatalf("slidingWindow() allowed, want rejected")
+			}
+
+			// 按 Retry-After 等待后重试，跨窗口时当前窗口计数成为上一窗口计数
+			retry := now.Add(time.Duration(seconds(result.RetryAfter)) * time.Second)
+			prev, cur := tt.prev, tt.cur
+			switch retry.Sub(start) / window {
+			case 0:
+			case 1:
+				prev, cur = tt.cur, 0
+			default:
+				prev, cur = 0, 0
+			}
+
+			if _, ok := slidingWindow(retry, window, tt.limit, prev, cur); !ok {
+				t.Errorf("slidingWindow() after Retry-After %v rejected, want allowed", result.RetryAfter)
+			}
+		})
+	}
+}
@@ -0,0 +1,595 @@
+package request
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-nt/nt/http/codec"
+)
+
+// 绑定数据来源
+const (
+	BindParam  = "param"
+	BindGet    = "get"
+	BindPost   = "post"
+	BindHeader = "header"
+	BindCookie = "cookie"
+	BindJson   = "json"
+	BindBody   = "body"
+)
+
+// 未指定来源时 Bind 使用的数据来源
+var defaultBindSources = []string{BindParam, BindGet, BindPost, BindBody}
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// GetBind 绑定 GET 参数，绑定后按 validate 标签校验
+func (d *Driver) GetBind(ptr any) error {
+	return d.Bind(ptr, BindGet)
+}
+
+// PostBind 绑定 POST 参数，绑定后按 validate 标签校验
+func (d *Driver) PostBind(ptr any) error {
+	return d.Bind(ptr, BindPost)
+}
+
+// ParamBind 绑定路由参数，绑定后按 validate 标签校验
+func (d *Driver) ParamBind(ptr any) error {
+	return d.Bind(ptr, BindParam)
+}
+
+// HeaderBind 绑定头信息，绑定后按 validate 标签校验
+func (d *Driver) HeaderBind(ptr any) error {
+	return d.Bind(ptr, BindHeader)
+}
+
+// CookieBind 绑定 cookie，绑定后按 validate 标签校验
+func (d *Driver) CookieBind(ptr any) error {
+	return d.Bind(ptr, BindCookie)
+}
+
+// BodyBind 按 Content-Type 选择解码器绑定请求体，绑定后按 validate 标签校验
+func (d *Driver) BodyBind(ptr any) error {
+	return d.Bind(ptr, BindBody)
+}
+
+// BodyJsonBind 绑定 JSON 请求体，绑定后按 validate 标签校验
+func (d *Driver) BodyJsonBind(ptr any) error {
+	return d.Bind(ptr, BindJson)
+}
+
+// SetStrictBind 设置当前请求是否严格绑定，未设置时使用 strictBind 配置
+// 严格绑定时无法转换的值返回校验错误，否则忽略该值
+func (d *Driver) SetStrictBind(strict bool) {
+	d.strictBind = &strict
+}
+
+// Bind 从多个来源绑定数据到结构体，绑定后按 validate 标签校验
+// sources 可选 param, get, post, header, cookie, json, body，为空时使用 param, get, post, body
+// body 按 Content-Type 从 codec 中选择解码器，如 JSON、XML、YAML、MessagePack
+// 请求体最先解析，其它来源按顺序查找，先找到的值生效
+// 字段名取 bind 标签，嵌套结构体及 map 以 name[key] 形式取值，切片以 name[] 或重复的 name 取值
+// 所有来源均无值且字段为零值时，使用 default 标签的值；time.Time 按 time_format 标签的格式解析
+func (d *Driver) Bind(ptr any, sources ...string) error {
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.New("request bind error: param of ptr is not a pointer")
+	}
+
+	if len(sources) == 0 {
+		sources = defaultBindSources
+	}
+
+	b := &binder{
+		d:      d,
+		strict: getConfig().strictBind,
+	}
+	if d.strictBind != nil {
+		b.strict = *d.strictBind
+	}
+
+	for _, source := range sources {
+		switch source {
+		case BindJson, "body-json":
+			// 仅绑定 JSON 时请求体必须为 JSON，否则仅在 Content-Type 为 JSON 且有请求体时解析
+			if len(sources) > 1 && (!strings.Contains(d.Header("Content-Type", ""), "json") || d.Request.ContentLength == 0) {
+				continue
+			}
+
+			bodyBytes, err := d.ReadBody()
+			if err != nil {
+				return err
+			}
+
+			if err = json.Unmarshal(bodyBytes, ptr); err != nil {
+				return err
+			}
+		case BindBody:
+			contentType := d.Header("Content-Type", "")
+			c, ok := codec.Get(contentType)
+
+			// 绑定多个来源时，表单等未注册解码器的类型及空请求体跳过
+			if len(sources) > 1 && (!ok || d.Request.ContentLength == 0) {
+				continue
+			}
+			if !ok {
+				return errors.New("request bind error: content type(" + contentType + ") is not supported")
+			}
+
+			bodyBytes, err := d.ReadBody()
+			if err != nil {
+				return err
+			}
+
+			if err = c.Unmarshal(bodyBytes, ptr); err != nil {
+				return err
+			}
+		case BindParam, BindGet, BindPost, BindHeader, BindCookie:
+			b.sources = append(b.sources, source)
+		default:
+			return errors.New("request bind error: source(" + source + ") is not supported")
+		}
+	}
+
+	rv = rv.Elem()
+	if rv.Kind() != reflect.Struct {
+		// 请求体为切片等非结构体的情况
+		if len(b.sources) == 0 {
+			return Validate(ptr)
+		}
+		return errors.New("request bind error: param of ptr is not a struct")
+	}
+
+	b.bindStruct(rv, "")
+
+	if len(b.errors) > 0 {
+		return &ValidationError{Errors: b.errors}
+	}
+
+	return Validate(ptr)
+}
+
+// binder 一次绑定过程
+type binder struct {
+	d       *Driver
+	sources []string
+	strict  bool
+	errors  []*FieldError
+}
+
+// bindStruct 绑定结构体各字段，返回是否有字段绑定到值
+func (b *binder) bindStruct(rv reflect.Value, prefix string) bool {
+	rt := rv.Type()
+	bound := false
+
+	for i := 0; i < rv.NumField(); i++ {
+		rvField := rv.Field(i)
+		rtField := rt.Field(i)
+
+		tag := rtField.Tag.Get("bind")
+		if tag == "-" || !rtField.IsExported() || !rvField.CanSet() {
+			continue
+		}
+
+		// 未指定名称的嵌入结构体，字段平铺绑定
+		if rtField.Anonymous && tag == "" && rtField.Type.Kind() == reflect.Struct {
+			if b.bindStruct(rvField, prefix) {
+				bound = true
+			}
+			continue
+		}
+
+		name := fieldName(rtField)
+		if prefix != "" {
+			name = prefix + "[" + name + "]"
+		}
+
+		if b.bindField(rvField, rtField, name) {
+			bound = true
+			continue
+		}
+
+		if defaultValue, ok := rtField.Tag.Lookup("default"); ok && rvField.IsZero() {
+			b.setDefault(rvField, rtField, name, defaultValue)
+		}
+	}
+
+	return bound
+}
+
+// bindField 绑定单个字段，返回是否绑定到值
+func (b *binder) bindField(rv reflect.Value, field reflect.StructField, name string) bool {
+	rt := rv.Type()
+
+	if rt.Kind() == reflect.Ptr {
+		// 指向结构体的指针仅在存在 name[ 前缀的值时绑定，避免自引用类型无限递归
+		elemType := rt.Elem()
+		for elemType.Kind() == reflect.Ptr {
+			elemType = elemType.Elem()
+		}
+		if elemType.Kind() == reflect.Struct && !isScalar(elemType) && !b.hasPrefix(name+"[") {
+			return false
+		}
+
+		if !rv.IsNil() {
+			return b.bindField(rv.Elem(), field, name)
+		}
+
+		elem := reflect.New(rt.Elem())
+		if b.bindField(elem.Elem(), field, name) {
+			rv.Set(elem)
+			return true
+		}
+		return false
+	}
+
+	if isScalar(rt) {
+		values, _ := b.lookup(name)
+		if len(values) == 0 {
+			return false
+		}
+
+		if err := setScalar(rv, values[0], field); err != nil {
+			b.fail(rv, name)
+		}
+		return true
+	}
+
+	switch rt.Kind() {
+	case reflect.Struct:
+		return b.bindStruct(rv, name)
+
+	case reflect.Slice:
+		if !isScalar(rt.Elem()) {
+			return false
+		}
+
+		values, source := b.lookup(name + "[]")
+		if len(values) == 0 {
+			values, source = b.lookup(name)
+		}
+		if len(values) == 0 {
+			return false
+		}
+
+		// cookie 中的多个值以逗号分隔
+		if source == BindCookie && len(values) == 1 {
+			values = strings.Split(values[0], ",")
+		}
+
+		newSlice := reflect.MakeSlice(rt, 0, len(values))
+		for i, value := range values {
+			elem := reflect.New(rt.Elem()).Elem()
+			if err := setScalar(elem, value, field); err != nil {
+				b.fail(elem, name+"["+strconv.Itoa(i)+"]")
+				continue
+			}
+			newSlice = reflect.Append(newSlice, elem)
+		}
+		rv.Set(newSlice)
+		return true
+
+	case reflect.Map:
+		if rt.Key().Kind() != reflect.String || !isScalar(rt.Elem()) {
+			return false
+		}
+
+		keys := b.keys(name)
+		if len(keys) == 0 {
+			return false
+		}
+
+		if rv.IsNil() {
+			rv.Set(reflect.MakeMapWithSize(rt, len(keys)))
+		}
+		for _, key := range keys {
+			values, _ := b.lookup(name + "[" + key + "]")
+			if len(values) == 0 {
+				continue
+			}
+
+			elem := reflect.New(rt.Elem()).Elem()
+			if err := setScalar(elem, values[0], field); err != nil {
+				b.fail(elem, name+"["+key+"]")
+				continue
+			}
+			rv.SetMapIndex(reflect.ValueOf(key).Convert(rt.Key()), elem)
+		}
+		return true
+	}
+
+	return false
+}
+
+// setDefault 设置 default 标签的值，切片以逗号分隔
+func (b *binder) setDefault(rv reflect.Value, field reflect.StructField, name string, defaultValue string) {
+	rt := rv.Type()
+
+	if rt.Kind() == reflect.Ptr {
+		elem := reflect.New(rt.Elem())
+		b.setDefault(elem.Elem(), field, name, defaultValue)
+		rv.Set(elem)
+		return
+	}
+
+	if rt.Kind() == reflect.Slice && isScalar(rt.Elem()) {
+		values := strings.Split(defaultValue, ",")
+		newSlice := reflect.MakeSlice(rt, 0, len(values))
+		for _, value := range values {
+			elem := reflect.New(rt.Elem()).Elem()
+			if err := setScalar(elem, strings.TrimSpace(value), field); err != nil {
+				b.fail(elem, name)
+				return
+			}
+			newSlice = reflect.Append(newSlice, elem)
+		}
+		rv.Set(newSlice)
+		return
+	}
+
+	if isScalar(rt) {
+		if err := setScalar(rv, defaultValue, field); err != nil {
+			b.fail(rv, name)
+		}
+	}
+}
+
+// fail 记录无法转换的值，仅严格绑定时返回
+func (b *binder) fail(rv reflect.Value, name string) {
+	if !b.strict {
+		return
+	}
+
+	name = fieldPath(name)
+	b.errors = append(b.errors, &FieldError{
+		Field:   name,
+		Rule:    "type",
+		Message: name + " must be a valid " + typeName(rv.Type()),
+	})
+}
+
+// lookup 按来源顺序查找值，返回值及其来源
+func (b *binder) lookup(name string) ([]string, string) {
+	for _, source := range b.sources {
+		var values []string
+
+		switch source {
+		case BindParam:
+			if value, ok := b.d.dParam[name]; ok {
+				values = []string{value}
+			}
+		case BindGet:
+			values = b.d.dGet[name]
+		case BindPost:
+			_ = b.d.ParseForm()
+			values = b.d.dPost[name]
+		case BindHeader:
+			values = b.d.Request.Header.Values(name)
+		case BindCookie:
+			for _, ck := range b.d.Request.Cookies() {
+				if ck.Name == name {
+					values = append(values, ck.Value)
+				}
+			}
+		}
+
+		if len(values) > 0 {
+			return values, source
+		}
+	}
+
+	return nil, ""
+}
+
+// hasPrefix 是否有来源存在以 prefix 开头的名称
+func (b *binder) hasPrefix(prefix string) bool {
+	for _, source := range b.sources {
+		switch source {
+		case BindParam:
+			for key := range b.d.dParam {
+				if strings.HasPrefix(key, prefix) {
+					return true
+				}
+			}
+		case BindGet:
+			for key := range b.d.dGet {
+				if strings.HasPrefix(key, prefix) {
+					return true
+				}
+			}
+		case BindPost:
+			_ = b.d.ParseForm()
+			for key := range b.d.dPost {
+				if strings.HasPrefix(key, prefix) {
+					return true
+				}
+			}
+		case BindHeader:
+			for key := range b.d.Request.Header {
+				if strings.HasPrefix(key, http.CanonicalHeaderKey(prefix)) {
+					return true
+				}
+			}
+		case BindCookie:
+			for _, ck := range b.d.Request.Cookies() {
+				if strings.HasPrefix(ck.Name, prefix) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// keys 查找 name[key] 形式的所有 key
+func (b *binder) keys(name string) []string {
+	prefix := name + "["
+	var keys []string
+	seen := make(map[string]bool)
+
+	add := func(key string) {
+		if !strings.HasPrefix(key, prefix) || !strings.HasSuffix(key, "]") {
+			return
+		}
+
+		key = key[len(prefix) : len(key)-1]
+		if key == "" || strings.ContainsAny(key, "[]") || seen[key] {
+			return
+		}
+
+		seen[key] = true
+		keys = append(keys, key)
+	}
+
+	for _, source := range b.sources {
+		switch source {
+		case BindParam:
+			for key := range b.d.dParam {
+				add(key)
+			}
+		case BindGet:
+			for key := range b.d.dGet {
+				add(key)
+			}
+		case BindPost:
+			_ = b.d.ParseForm()
+			for key := range b.d.dPost {
+				add(key)
+			}
+		case BindCookie:
+			for _, ck := range b.d.Request.Cookies() {
+				add(ck.Name)
+			}
+		}
+	}
+
+	return keys
+}
+
+// isScalar 是否为可由单个字符串转换的类型
+func isScalar(rt reflect.Type) bool {
+	if rt == timeType || reflect.PointerTo(rt).Implements(textUnmarshalerType) {
+		return true
+	}
+
+	switch rt.Kind() {
+	case reflect.String,
+		reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
+
+// setScalar 将字符串转换后设置到字段
+func setScalar(rv reflect.Value, value string, field reflect.StructField) error {
+	rt := rv.Type()
+
+	if rt == timeType {
+		t, err := parseTime(value, field.Tag.Get("time_format"))
+		if err != nil {
+			return err
+		}
+		rv.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	if reflect.PointerTo(rt).Implements(textUnmarshalerType) {
+		return rv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value))
+	}
+
+	switch rt.Kind() {
+	case reflect.String:
+		rv.SetString(value)
+	case reflect.Bool:
+		switch strings.ToLower(value) {
+		case "1", "true", "on", "yes":
+			rv.SetBool(true)
+		case "0", "false", "off", "no", "":
+			rv.SetBool(false)
+		default:
+			return errors.New("invalid bool value")
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		valInt64, err := strconv.ParseInt(value, 10, rt.Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetInt(valInt64)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		valUint64, err := strconv.ParseUint(value, 10, rt.Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetUint(valUint64)
+	case reflect.Float32, reflect.Float64:
+		valFloat64, err := strconv.ParseFloat(value, rt.Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetFloat(valFloat64)
+	default:
+		return errors.New("unsupported type")
+	}
+
+	return nil
+}
+
+// parseTime 解析时间，layout 为空时依次尝试 RFC3339、2006-01-02 15:04:05、2006-01-02
+// layout 为 unix / unixmilli 时按时间戳解析
+func parseTime(value string, layout string) (time.Time, error) {
+	switch layout {
+	case "unix", "unixmilli":
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if layout == "unix" {
+			return time.Unix(n, 0), nil
+		}
+		return time.UnixMilli(n), nil
+	case "":
+		var err error
+		for _, layout = range []string{time.RFC3339, time.DateTime, time.DateOnly} {
+			var t time.Time
+			if t, err = time.ParseInLocation(layout, value, time.Local); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, err
+	}
+
+	return time.ParseInLocation(layout, value, time.Local)
+}
+
+// typeName 错误信息中使用的类型名称
+func typeName(rt reflect.Type) string {
+	if rt == timeType {
+		return "time"
+	}
+
+	switch rt.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	}
+
+	return "value"
+}
@@ -0,0 +1,37 @@
+package request
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+type bindTestNode struct {
+	Name string        `bind:"name"`
+	Next *bindTestNode `bind:"next"`
+}
+
+func TestBindSelfReferentialStruct(t *testing.T) {
+	r := httptest.NewRequest("GET", "/?name=a&next[name]=b&next[next][name]=c", nil)
+	d := &Driver{}
+	d.Init(r)
+
+	var node bindTestNode
+	if err := d.GetBind(&node); err != nil {
+		t.Fatalf("GetBind() error = %v", err)
+	}
+
+	if node.Name != "a" || node.Next == nil || node.Next.Name != "b" || node.Next.Next == nil || node.Next.Next.Name != "c" {
+		t.Fatalf("GetBind() = %+v, want a -> b -> c", node)
+	}
+	if node.Next.Next.Next != nil {
+		t.Errorf("GetBind() allocated a node without input: %+v", node.Next.Next.Next)
+	}
+
+	var empty bindTestNode
+	if err := d.Bind(&empty, BindParam); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+	if empty.Next != nil {
+		t.Errorf("Bind() allocated a node without input: %+v", empty.Next)
+	}
+}
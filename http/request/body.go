@@ -0,0 +1,119 @@
+package request
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+)
+
+// ErrBodyTooLarge 请求体超出 maxBodySize 配置
+var ErrBodyTooLarge = errors.New("request body error: body is too large")
+
+// ErrBodyStreamed 请求体已通过 BodyStream 读取，无法再缓存
+var ErrBodyStreamed = errors.New("request body error: body has been streamed")
+
+// ReadBody 读取请求体，首次读取后缓存，可重复调用
+// 超出 maxBodySize 配置时返回 ErrBodyTooLarge，请求体不缓存，仍可通过 BodyStream、File 等读取
+func (d *Driver) ReadBody() ([]byte, error) {
+	if d.bodyRead {
+		return d.body, d.bodyErr
+	}
+	d.bodyRead = true
+
+	if d.bodyStreamed {
+		d.bodyErr = ErrBodyStreamed
+		return nil, d.bodyErr
+	}
+
+	if d.Request.Body == nil || d.Request.Body == http.NoBody {
+		return nil, nil
+	}
+
+	maxBodySize := getConfig().maxBodySize
+	if maxBodySize > 0 && d.Request.ContentLength > maxBodySize {
+		d.bodyErr = ErrBodyTooLarge
+		return nil, d.bodyErr
+	}
+
+	var reader io.Reader = d.Request.Body
+	if maxBodySize > 0 {
+		reader = io.LimitReader(reader, maxBodySize+1)
+	}
+
+	body := d.Request.Body
+	d.body, d.bodyErr = io.ReadAll(reader)
+	if d.bodyErr == nil && maxBodySize > 0 && int64(len(d.body)) > maxBodySize {
+		// 已读取的内容放回请求体，multipart 解析及 BodyStream 仍可读取完整内容
+		d.Request.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(d.body), body), body}
+		d.body, d.bodyErr = nil, ErrBodyTooLarge
+		return nil, d.bodyErr
+	}
+	_ = body.Close()
+
+	// 替换为缓存的请求体，供直接读取 Request.Body 的代码使用
+	d.Request.Body = io.NopCloser(bytes.NewReader(d.body))
+
+	return d.body, d.bodyErr
+}
+
+// BodyStream 以流的方式读取请求体，用于不宜缓存的大请求体，不受 maxBodySize 限制
+// 请求体已缓存时返回缓存内容，调用后 Body、BodyBytes、Json 及请求体绑定不再可用
+func (d *Driver) BodyStream() io.Reader {
+	if d.bodyRead && d.bodyErr == nil {
+		return bytes.NewReader(d.body)
+	}
+
+	d.bodyStreamed = true
+	if d.Request.Body == nil {
+		return http.NoBody
+	}
+
+	return d.Request.Body
+}
+
+// ParseForm 解析表单并填充 Request.PostForm 及 Request.Form，Post、Bind 等方法首次访问 POST 数据时自动调用
+// urlencoded 表单从缓存的请求体解析，解析后请求体仍可读取，multipart 表单由 net/http 解析
+// 直接读取 Request.Form 或 Request.PostForm 前须先调用，不要调用 Request.ParseForm，否则请求体会被读取且不再缓存
+func (d *Driver) ParseForm() error {
+	if d.isMultipart() {
+		return d.parseMultipart()
+	}
+
+	if d.formParsed {
+		return d.formErr
+	}
+	d.formParsed = true
+
+	d.dPost = make(url.Values)
+
+	switch d.Request.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		mediaType, _, _ := mime.ParseMediaType(d.Request.Header.Get("Content-Type"))
+		if mediaType == "application/x-www-form-urlencoded" {
+			body, err := d.ReadBody()
+			if err == nil {
+				d.dPost, err = url.ParseQuery(string(body))
+			}
+			d.formErr = err
+		}
+	}
+
+	// 与 net/http 一致，Form 中 POST 数据在前，GET 数据在后
+	form := make(url.Values, len(d.dPost)+len(d.dGet))
+	for name, values := range d.dPost {
+		form[name] = append(form[name], values...)
+	}
+	for name, values := range d.dGet {
+		form[name] = append(form[name], values...)
+	}
+
+	d.Request.PostForm = d.dPost
+	d.Request.Form = form
+	return d.formErr
+}
@@ -0,0 +1,84 @@
+package request
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseFormKeepsBody(t *testing.T) {
+	r := httptest.NewRequest("POST", "/?a=3&c=4", strings.NewReader("a=1&b=2"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	d := &Driver{}
+	d.Init(r)
+
+	if err := d.ParseForm(); err != nil {
+		t.Fatalf("ParseForm() error = %v", err)
+	}
+
+	if got := r.PostForm.Get("b"); got != "2" {
+		t.Errorf("Request.PostForm.Get(b) = %q, want %q", got, "2")
+	}
+	if got := r.Form["a"]; len(got) != 2 || got[0] != "1" || got[1] != "3" {
+		t.Errorf("Request.Form[a] = %v, want [1 3]", got)
+	}
+	if got := r.FormValue("c"); got != "4" {
+		t.Errorf("Request.FormValue(c) = %q, want %q", got, "4")
+	}
+	if got := d.Post("a", ""); got != "1" {
+		t.Errorf("Post(a) = %q, want %q", got, "1")
+	}
+	if body, err := d.ReadBody(); err != nil || string(body) != "a=1&b=2" {
+		t.Errorf("ReadBody() = %q, %v, want %q", body, err, "a=1&b=2")
+	}
+}
+
+func TestParseFormGet(t *testing.T) {
+	r := httptest.NewRequest("GET", "/?a=1", nil)
+
+	d := &Driver{}
+	d.Init(r)
+
+	if err := d.ParseForm(); err != nil {
+		t.Fatalf("ParseForm() error = %v", err)
+	}
+	if r.PostForm == nil || len(r.PostForm) != 0 {
+		t.Errorf("Request.PostForm = %v, want empty", r.PostForm)
+	}
+	if got := r.Form.Get("a"); got != "1" {
+		t.Errorf("Request.Form.Get(a) = %q, want %q", got, "1")
+	}
+}
+
+func TestReadBodyTooLargeMultipart(t *testing.T) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	part, _ := w.CreateFormFile("file", "a.txt")
+	_, _ = part.Write([]byte(strings.Repeat("x", 4096)))
+	_ = w.Close()
+
+	defer initConfig()
+	initConfig()
+	if err := SetConfig(map[string]any{"maxBodySize": 1024}); err != nil {
+		t.Fatalf("SetConfig() error = %v", err)
+	}
+
+	r := httptest.NewRequest("POST", "/upload", io.NopCloser(bytes.NewReader(buf.Bytes())))
+	r.Header.Set("Content-Type", w.FormDataContentType())
+	r.ContentLength = -1
+
+	d := &Driver{}
+	d.Init(r)
+
+	if _, err := d.ReadBody(); err != ErrBodyTooLarge {
+		t.Fatalf("ReadBody() error = %v, want %v", err, ErrBodyTooLarge)
+	}
+
+	if fh, err := d.File("file"); err != nil || fh.Size != 4096 {
+		t.Errorf("File() = %v, %v, want size 4096", fh, err)
+	}
+}
@@ -0,0 +1,309 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/go-ini/ini"
+)
+
+var config *Config
+
+type Config struct {
+	// 缓存请求体的最大字节数，超出时读取请求体返回错误，0-不限制
+	maxBodySize int64
+
+	// multipart 表单的内存缓冲字节数，超出部分写入临时文件
+	maxMemory int64
+
+	// 单个上传文件最大字节数，0-不限制
+	maxFileSize int64
+
+	// 解析 multipart 表单时请求体的最大字节数，防止上传内容占满临时文件目录，0-不限制
+	maxMultipartSize int64
+
+	// 允许上传的文件扩展名，如 .jpg，为空时不限制
+	allowedExts []string
+
+	// 允许上传的文件 MIME 类型，支持 image/* 形式的前缀匹配，为空时不限制
+	allowedMimes []string
+
+	// 是否严格绑定，严格绑定时无法转换的值返回校验错误
+	strictBind bool
+
+	// 可信代理的网段，仅信任来自这些地址的转发头
+	trustedProxies []netip.Prefix
+
+	// 是否信任 unix socket 连接的转发头
+	trustUnixSocket bool
+
+	// 可信代理使用的转发头 X-Forwarded-For | Forwarded | X-Real-IP，仅解析该转发头
+	forwardedHeader string
+}
+
+// initConfig 初始化配置
+func initConfig() {
+	config = &Config{
+		maxBodySize:      10 << 20,
+		maxMemory:        32 << 20,
+		maxFileSize:      0,
+		maxMultipartSize: 100 << 20,
+		allowedExts:      nil,
+		allowedMimes:     nil,
+		strictBind:       false,
+		forwardedHeader:  "X-Forwarded-For",
+	}
+}
+
+// getConfig 获取配置，未设置时使用默认配置
+func getConfig() *Config {
+	if config == nil {
+		initConfig()
+	}
+
+	return config
+}
+
+// SetConfig 参数配置
+func SetConfig(c map[string]any) error {
+	if config == nil {
+		initConfig()
+	}
+
+	for key, value := range c {
+		switch key {
+		case "maxBodySize", "max_body_size":
+			switch t := value.(type) {
+			case int:
+				if t >= 0 {
+					config.maxBodySize = int64(t)
+				} else {
+					return errors.New("request config parameter(max_body_size) is not a valid value")
+				}
+			case int64:
+				if t >= 0 {
+					config.maxBodySize = t
+				} else {
+					return errors.New("request config parameter(max_body_size) is not a valid value")
+				}
+			}
+		case "maxMemory", "max_memory":
+			switch t := value.(type) {
+			case int:
+				if t > 0 {
+					config.maxMemory = int64(t)
+				} else {
+					return errors.New("request config parameter(max_memory) is not a valid value")
+				}
+			case int64:
+				if t > 0 {
+					config.maxMemory = t
+				} else {
+					return errors.New("request config parameter(max_memory) is not a valid value")
+				}
+			}
+		case "maxFileSize", "max_file_size":
+			switch t := value.(type) {
+			case int:
+				if t >= 0 {
+					config.maxFileSize = int64(t)
+				} else {
+					return errors.New("request config parameter(max_file_size) is not a valid value")
+				}
+			case int64:
+				if t >= 0 {
+					config.maxFileSize = t
+				} else {
+					return errors.New("request config parameter(max_file_size) is not a valid value")
+				}
+			}
+		case "maxMultipartSize", "max_multipart_size":
+			switch t := value.(type) {
+			case int:
+				if t >= 0 {
+					config.maxMultipartSize = int64(t)
+				} else {
+					return errors.New("request config parameter(max_multipart_size) is not a valid value")
+				}
+			case int64:
+				if t >= 0 {
+					config.maxMultipartSize = t
+				} else {
+					return errors.New("request config parameter(max_multipart_size) is not a valid value")
+				}
+			}
+		case "allowedExts", "allowed_exts":
+			switch t := value.(type) {
+			case []string:
+				config.allowedExts = formatExts(t)
+			case string:
+				config.allowedExts = formatExts(strings.Split(t, ","))
+			}
+		case "allowedMimes", "allowed_mimes":
+			switch t := value.(type) {
+			case []string:
+				config.allowedMimes = formatList(t)
+			case string:
+				config.allowedMimes = formatList(strings.Split(t, ","))
+			}
+		case "strictBind", "strict_bind":
+			switch t := value.(type) {
+			case bool:
+				config.strictBind = t
+			case string:
+				config.strictBind = t == "true" || t == "1"
+			}
+		case "trustedProxies", "trusted_proxies":
+			var items []string
+			switch t := value.(type) {
+			case []string:
+				items = t
+			case string:
+				items = strings.Split(t, ",")
+			default:
+				return errors.New("request config parameter(trusted_proxies) is not a valid value")
+			}
+
+			prefixes, trustUnixSocket, ok := parseTrustedProxies(items)
+			if !ok {
+				return errors.New("request config parameter(trusted_proxies) is not a valid value")
+			}
+			config.trustedProxies = prefixes
+			config.trustUnixSocket = trustUnixSocket
+		case "forwardedHeader", "forwarded_header":
+			t, ok := value.(string)
+			if !ok || formatForwardedHeader(t) == "" {
+				return errors.New("request config parameter(forwarded_header) is not a valid value")
+			}
+			config.forwardedHeader = formatForwardedHeader(t)
+		}
+	}
+
+	return nil
+}
+
+// SetIniConfig 设置 ini 配置
+func SetIniConfig(section *ini.Section) error {
+	if config == nil {
+		initConfig()
+	}
+
+	configKeyMaxBodySize, err := section.GetKey("maxBodySize")
+	if err == nil {
+		t, err := configKeyMaxBodySize.Int64()
+		if err == nil && t >= 0 {
+			config.maxBodySize = t
+		} else {
+			return errors.New("request config parameter(maxBodySize) is not a valid value")
+		}
+	}
+
+	configKeyMaxMemory, err := section.GetKey("maxMemory")
+	if err == nil {
+		t, err := configKeyMaxMemory.Int64()
+		if err == nil && t > 0 {
+			config.maxMemory = t
+		} else {
+			return errors.New("request config parameter(maxMemory) is not a valid value")
+		}
+	}
+
+	configKeyMaxFileSize, err := section.GetKey("maxFileSize")
+	if err == nil {
+		t, err := configKeyMaxFileSize.Int64()
+		if err == nil && t >= 0 {
+			config.maxFileSize = t
+		} else {
+			return errors.New("request config parameter(maxFileSize) is not a valid value")
+		}
+	}
+
+	configKeyMaxMultipartSize, err := section.GetKey("maxMultipartSize")
+	if err == nil {
+		t, err := configKeyMaxMultipartSize.Int64()
+		if err == nil && t >= 0 {
+			config.maxMultipartSize = t
+		} else {
+			return errors.New("request config parameter(maxMultipartSize) is not a valid value")
+		}
+	}
+
+	configKeyAllowedExts, err := section.GetKey("allowedExts")
+	if err == nil {
+		config.allowedExts = formatExts(strings.Split(configKeyAllowedExts.String(), ","))
+	}
+
+	configKeyAllowedMimes, err := section.GetKey("allowedMimes")
+	if err == nil {
+		config.allowedMimes = formatList(strings.Split(configKeyAllowedMimes.String(), ","))
+	}
+
+	configKeyStrictBind, err := section.GetKey("strictBind")
+	if err == nil {
+		t, err := configKeyStrictBind.Bool()
+		if err == nil {
+			config.strictBind = t
+		} else {
+			return errors.New("request config parameter(strictBind) is not a valid value")
+		}
+	}
+
+	configKeyTrustedProxies, err := section.GetKey("trustedProxies")
+	if err == nil {
+		prefixes, trustUnixSocket, ok := parseTrustedProxies(strings.Split(configKeyTrustedProxies.String(), ","))
+		if !ok {
+			return errors.New("request config parameter(trustedProxies) is not a valid value")
+		}
+		config.trustedProxies = prefixes
+		config.trustUnixSocket = trustUnixSocket
+	}
+
+	configKeyForwardedHeader, err := section.GetKey("forwardedHeader")
+	if err == nil {
+		t := formatForwardedHeader(configKeyForwardedHeader.String())
+		if t == "" {
+			return errors.New("request config parameter(forwardedHeader) is not a valid value")
+		}
+		config.forwardedHeader = t
+	}
+
+	return nil
+}
+
+// formatForwardedHeader 格式化转发头名称，不支持时返回空
+func formatForwardedHeader(header string) string {
+	header = http.CanonicalHeaderKey(strings.TrimSpace(header))
+	switch header {
+	case "X-Forwarded-For", "Forwarded", "X-Real-Ip":
+		return header
+	}
+
+	return ""
+}
+
+// formatList 去除空白项并转为小写
+func formatList(items []string) []string {
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if item != "" {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
+
+// formatExts 格式化扩展名，统一以 . 开头
+func formatExts(items []string) []string {
+	list := formatList(items)
+	for i, ext := range list {
+		if !strings.HasPrefix(ext, ".") {
+			list[i] = "." + ext
+		}
+	}
+
+	return list
+}
@@ -2,13 +2,9 @@ package request
 
 import (
 	"encoding/json"
-	"errors"
-	"io"
 	"net"
 	"net/http"
 	"net/url"
-	"reflect"
-	"strconv"
 	"strings"
 )
 
@@ -16,18 +12,32 @@ type Driver struct {
 	Request *http.Request
 	dGet    url.Values
 	dPost   url.Values
+	dParam  map[string]string
+
+	// multipart 表单是否已解析及解析错误
+	multipartParsed bool
+	multipartErr    error
+
+	// urlencoded 表单是否已解析及解析错误
+	formParsed bool
+	formErr    error
+
+	// 是否严格绑定，nil 时使用 strictBind 配置
+	strictBind *bool
+
+	// 经可信代理转发的客户端信息，首次使用时解析
+	proxy *forwarded
+
+	// 缓存的请求体及读取错误
+	body         []byte
+	bodyErr      error
+	bodyRead     bool
+	bodyStreamed bool
 }
 
 func (d *Driver) Init(request *http.Request) {
 	d.Request = request
 	d.dGet = request.URL.Query()
-
-	err := request.ParseForm()
-	if err != nil {
-		return
-	}
-
-	d.dPost = request.PostForm
 }
 
 // Get 获取 string 类型的 GET 数据
@@ -70,6 +80,8 @@ func (d *Driver) GetMap() map[string][]string {
 
 // Post 获取 string 类型的 POST 数据
 func (d *Driver) Post(name string, defaultValue string) string {
+	_ = d.ParseForm()
+
 	if values, ok := d.dPost[name]; ok {
 		if len(values) > 0 {
 			return values[0]
@@ -81,6 +93,8 @@ func (d *Driver) Post(name string, defaultValue string) string {
 
 // PostArray 获取 string 数组 类型的 POST 数据
 func (d *Driver) PostArray(name string) []string {
+	_ = d.ParseForm()
+
 	if values, ok := d.dPost[name]; ok {
 		return values
 	}
@@ -90,6 +104,8 @@ func (d *Driver) PostArray(name string) []string {
 
 // PostFormat 获取 POST 格式化数据
 func (d *Driver) PostFormat(name string) *Format {
+	_ = d.ParseForm()
+
 	if values, ok := d.dPost[name]; ok {
 		if len(values) > 0 {
 			return &Format{
@@ -103,12 +119,49 @@ func (d *Driver) PostFormat(name string) *Format {
 
 // PostMap 获取 所有 POST 数据
 func (d *Driver) PostMap() map[string][]string {
+	_ = d.ParseForm()
+
 	return d.dPost
 }
 
-// Body 获取请求休
+// SetParams 设置路由参数
+func (d *Driver) SetParams(params map[string]string) {
+	d.dParam = params
+}
+
+// Param 获取路由参数
+func (d *Driver) Param(name string, defaultValue string) string {
+	if value, ok := d.dParam[name]; ok {
+		return value
+	}
+
+	return defaultValue
+}
+
+// ParamFormat 获取路由参数格式化数据
+func (d *Driver) ParamFormat(name string) *Format {
+	if value, ok := d.dParam[name]; ok {
+		return &Format{
+			Value: value,
+		}
+	}
+
+	return &Format{}
+}
+
+// PathFormat 获取路由路径参数格式化数据，同 ParamFormat
+func (d *Driver) PathFormat(name string) *Format {
+	return d.ParamFormat(name)
+}
+
+// ParamMap 获取 所有 路由参数
+func (d *Driver) ParamMap() map[string]string {
+	return d.dParam
+}
+
+// Body 获取请求体，可重复读取
 func (d *Driver) Body(defaultValue string) string {
-	bodyBytes, err := io.ReadAll(d.Request.Body)
+	bodyBytes, err := d.ReadBody()
 	if err != nil {
 		return defaultValue
 	}
@@ -116,9 +169,9 @@ func (d *Driver) Body(defaultValue string) string {
 	return string(bodyBytes)
 }
 
-// Body 获取请求休
+// BodyBytes 获取请求体，可重复读取
 func (d *Driver) BodyBytes(defaultValue []byte) []byte {
-	bodyBytes, err := io.ReadAll(d.Request.Body)
+	bodyBytes, err := d.ReadBody()
 	if err != nil {
 		return defaultValue
 	}
@@ -126,9 +179,9 @@ func (d *Driver) BodyBytes(defaultValue []byte) []byte {
 	return bodyBytes
 }
 
-// Json 获取请求休并尝试转为 JSON 格式
+// Json 获取请求体并尝试转为 JSON 格式
 func (d *Driver) Json(defaultValue any) any {
-	bodyBytes, err := io.ReadAll(d.Request.Body)
+	bodyBytes, err := d.ReadBody()
 	if err != nil {
 		return defaultValue
 	}
@@ -142,9 +195,9 @@ func (d *Driver) Json(defaultValue any) any {
 	return data
 }
 
-// Header 获取头信息
+// Header 获取头信息，名称不区分大小写
 func (d *Driver) Header(name string, defaultValue string) string {
-	if values, ok := d.Request.Header[name]; ok {
+	if values, ok := d.Request.Header[http.CanonicalHeaderKey(name)]; ok {
 		if len(values) > 0 {
 			return values[0]
 		}
@@ -153,9 +206,9 @@ func (d *Driver) Header(name string, defaultValue string) string {
 	return defaultValue
 }
 
-// HeaderFormat 获取头信息
+// HeaderFormat 获取头信息格式化数据，名称不区分大小写
 func (d *Driver) HeaderFormat(name string) *Format {
-	if values, ok := d.Request.Header[name]; ok {
+	if values, ok := d.Request.Header[http.CanonicalHeaderKey(name)]; ok {
 		if len(values) > 0 {
 			return &Format{
 				Value: values[0],
@@ -166,9 +219,9 @@ func (d *Driver) HeaderFormat(name string) *Format {
 	return &Format{}
 }
 
-// HeaderArray 获取 string 数组 类型的 头信息
+// HeaderArray 获取 string 数组 类型的 头信息，名称不区分大小写
 func (d *Driver) HeaderArray(name string) []string {
-	if values, ok := d.Request.Header[name]; ok {
+	if values, ok := d.Request.Header[http.CanonicalHeaderKey(name)]; ok {
 		return values
 	}
 
@@ -189,6 +242,18 @@ func (d *Driver) Cookie(name string, defaultValue string) string {
 	return ck.Value
 }
 
+// CookieFormat 获取 cookie 格式化数据
+func (d *Driver) CookieFormat(name string) *Format {
+	ck, err := d.Request.Cookie(name)
+	if err != nil {
+		return &Format{}
+	}
+
+	return &Format{
+		Value: ck.Value,
+	}
+}
+
 // Url 网址
 func (d *Driver) Url() string {
 	return d.Scheme() + "://" + d.Host() + d.Request.RequestURI
@@ -199,9 +264,9 @@ func (d *Driver) RootUrl() string {
 	return d.Scheme() + "://" + d.Host()
 }
 
-// Scheme 请求协议 "http"|"https".
+// Scheme 请求协议 "http"|"https"，对端为可信代理时取转发头中的协议
 func (d *Driver) Scheme() string {
-	if scheme := d.Header("X-Forwarded-Proto", ""); scheme != "" {
+	if scheme := strings.ToLower(d.forwarded().proto); scheme == "http" || scheme == "https" {
 		return scheme
 	}
 	if d.Request.URL.Scheme != "" {
@@ -215,16 +280,18 @@ func (d *Driver) Scheme() string {
 
 // Domain 请求域名，不含端口号
 func (d *Driver) Domain() string {
-	if d.Request.Host != "" {
-		if domain, _, err := net.SplitHostPort(d.Request.Host); err == nil {
-			return domain
-		}
+	host := d.Host()
+	if domain, _, err := net.SplitHostPort(host); err == nil {
+		return domain
 	}
-	return "localhost"
+	return host
 }
 
-// Host 请求主机名，可能包含端口号
+// Host 请求主机名，可能包含端口号，对端为可信代理时取转发头中的主机名
 func (d *Driver) Host() string {
+	if host := d.forwarded().host; host != "" {
+		return host
+	}
 	if d.Request.Host != "" {
 		return d.Request.Host
 	}
@@ -285,234 +352,3 @@ func (d *Driver) IsPatch() bool {
 func (d *Driver) IsAjax() bool {
 	return d.Header("X-Requested-With", "") == "XMLHttpRequest"
 }
-
-func (d *Driver) GetBind(ptr any) error {
-	return d.bind(ptr, "get")
-}
-
-func (d *Driver) PostBind(ptr any) error {
-	return d.bind(ptr, "post")
-}
-
-func (d *Driver) BodyJsonBind(ptr any) error {
-	return d.bind(ptr, "body-json")
-}
-
-func (d *Driver) bind(ptr any, dsType string) error {
-	rv := reflect.ValueOf(ptr)
-	if rv.Kind() != reflect.Ptr {
-		return errors.New("request bind error: param of ptr is not a pointer")
-	}
-
-	// json 中为切片的情况
-	if dsType == "body-json" {
-		bodyBytes, err := io.ReadAll(d.Request.Body)
-		if err != nil {
-			return err
-		}
-
-		err = json.Unmarshal(bodyBytes, ptr)
-		if err != nil {
-			return err
-		}
-
-		return nil
-	}
-
-	rv = rv.Elem()
-
-	return d.bindValue(rv, dsType)
-}
-
-// 绑定
-func (d *Driver) bindValue(rv reflect.Value, dsType string) error {
-	rt := rv.Type()
-	if rt.Kind() != reflect.Struct {
-		return errors.New("request bind error: param of ptr is not a struct")
-	}
-
-	for i := 0; i < rv.NumField(); i++ {
-		rvField := rv.Field(i)
-		rtField := rt.Field(i)
-
-		tag := rtField.Tag.Get("bind")
-		if tag == "-" {
-			continue
-		}
-
-		if len(rtField.Name) == 0 || !rvField.CanSet() {
-			continue
-		}
-
-		if tag == "" {
-			tag = rtField.Name
-		}
-
-		rvFieldKind := rvField.Kind()
-
-		switch rvFieldKind {
-		case reflect.String,
-			reflect.Bool,
-			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
-			reflect.Float32, reflect.Float64:
-
-			var val string
-			switch dsType {
-			case "get":
-				val = d.Get(tag, "")
-			case "post":
-				val = d.Post(tag, "")
-			case "header":
-				val = d.Header(tag, "")
-			case "cookie":
-				val = d.Cookie(tag, "")
-			}
-
-			switch rvFieldKind {
-			case reflect.String:
-				rvField.SetString(val)
-			case reflect.Bool:
-				if val == "true" || val == "1" {
-					rvField.SetBool(true)
-				} else {
-					rvField.SetBool(false)
-				}
-			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-				valInt64, err := strconv.ParseInt(val, 10, 64)
-				if err == nil {
-					rvField.SetInt(valInt64)
-				}
-			case reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-				valUint64, err := strconv.ParseUint(val, 10, 64)
-				if err == nil {
-					rvField.SetUint(valUint64)
-				}
-			case reflect.Float32, reflect.Float64:
-				valFloat64, err := strconv.ParseFloat(val, 64)
-				if err == nil {
-					rvField.SetFloat(valFloat64)
-				}
-			}
-
-		case reflect.Slice:
-
-			var vals []string
-			switch dsType {
-			case "get":
-				vals = d.GetArray(tag + "[]")
-			case "post":
-				vals = d.PostArray(tag + "[]")
-			case "header":
-				vals = d.HeaderArray(tag + "[]")
-			case "cookie":
-				vals = strings.Split(d.Cookie(tag, ""), ",")
-			}
-
-			if len(vals) > 0 {
-				newSlice := reflect.MakeSlice(rtField.Type, 0, len(vals))
-				switch rtField.Type.Elem().Kind() {
-				case reflect.String:
-					newSlice = reflect.AppendSlice(newSlice, reflect.ValueOf(vals))
-				case reflect.Bool:
-					for _, val := range vals {
-						if val == "true" || val == "1" {
-							newSlice = reflect.Append(newSlice, reflect.ValueOf(true))
-						} else {
-							newSlice = reflect.Append(newSlice, reflect.ValueOf(false))
-						}
-					}
-				case reflect.Int:
-					for _, val := range vals {
-						valInt64, err := strconv.ParseInt(val, 10, 64)
-						if err == nil {
-							newSlice = reflect.Append(newSlice, reflect.ValueOf(int(valInt64)))
-						}
-					}
-				case reflect.Int8:
-					for _, val := range vals {
-						valInt64, err := strconv.ParseInt(val, 10, 64)
-						if err == nil {
-							newSlice = reflect.Append(newSlice, reflect.ValueOf(int8(valInt64)))
-						}
-					}
-				case reflect.Int16:
-					for _, val := range vals {
-						valInt64, err := strconv.ParseInt(val, 10, 64)
-						if err == nil {
-							newSlice = reflect.Append(newSlice, reflect.ValueOf(int16(valInt64)))
-						}
-					}
-				case reflect.Int32:
-					for _, val := range vals {
-						valInt64, err := strconv.ParseInt(val, 10, 64)
-						if err == nil {
-							newSlice = reflect.Append(newSlice, reflect.ValueOf(int32(valInt64)))
-						}
-					}
-				case reflect.Int64:
-					for _, val := range vals {
-						valInt64, err := strconv.ParseInt(val, 10, 64)
-						if err == nil {
-							newSlice = reflect.Append(newSlice, reflect.ValueOf(valInt64))
-						}
-					}
-				case reflect.Uint:
-					for _, val := range vals {
-						valUint64, err := strconv.ParseUint(val, 10, 64)
-						if err == nil {
-							newSlice = reflect.Append(newSlice, reflect.ValueOf(uint(valUint64)))
-						}
-					}
-				case reflect.Uint8:
-					for _, val := range vals {
-						valUint64, err := strconv.ParseUint(val, 10, 64)
-						if err == nil {
-							newSlice = reflect.Append(newSlice, reflect.ValueOf(uint8(valUint64)))
-						}
-					}
-				case reflect.Uint16:
-					for _, val := range vals {
-						valUint64, err := strconv.ParseUint(val, 10, 64)
-						if err == nil {
-							newSlice = reflect.Append(newSlice, reflect.ValueOf(uint16(valUint64)))
-						}
-					}
-				case reflect.Uint32:
-					for _, val := range vals {
-						valUint64, err := strconv.ParseUint(val, 10, 64)
-						if err == nil {
-							newSlice = reflect.Append(newSlice, reflect.ValueOf(uint32(valUint64)))
-						}
-					}
-				case reflect.Uint64:
-					for _, val := range vals {
-						valUint64, err := strconv.ParseUint(val, 10, 64)
-						if err == nil {
-							newSlice = reflect.Append(newSlice, reflect.ValueOf(valUint64))
-						}
-					}
-				case reflect.Float32:
-					for _, val := range vals {
-						valFloat64, err := strconv.ParseFloat(val, 64)
-						if err == nil {
-							newSlice = reflect.Append(newSlice, reflect.ValueOf(float32(valFloat64)))
-						}
-					}
-				case reflect.Float64:
-					for _, val := range vals {
-						valFloat64, err := strconv.ParseFloat(val, 64)
-						if err == nil {
-							newSlice = reflect.Append(newSlice, reflect.ValueOf(valFloat64))
-						}
-					}
-				}
-
-				rvField.Set(newSlice)
-			}
-
-		}
-	}
-
-	return nil
-}
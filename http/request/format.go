@@ -2,6 +2,8 @@ package request
 
 import (
 	"strconv"
+	"strings"
+	"time"
 )
 
 type Format struct {
@@ -158,7 +160,14 @@ func (f *Format) Uint32(defaultValue uint32) uint32 {
 }
 
 // Unt64 格式化为 uint64
+//
+// Deprecated: 使用 Uint64
 func (f *Format) Unt64(defaultValue uint64) uint64 {
+	return f.Uint64(defaultValue)
+}
+
+// Uint64 格式化为 uint64
+func (f *Format) Uint64(defaultValue uint64) uint64 {
 	if f.Value == "" {
 		return defaultValue
 	}
@@ -198,3 +207,129 @@ func (f *Format) Float64(defaultValue float64) float64 {
 
 	return val
 }
+
+// String 格式化为 string
+func (f *Format) String(defaultValue string) string {
+	if f.Value == "" {
+		return defaultValue
+	}
+
+	return f.Value
+}
+
+// Bool 格式化为 bool，支持 1/0、true/false、on/off、yes/no
+func (f *Format) Bool(defaultValue bool) bool {
+	switch strings.ToLower(strings.TrimSpace(f.Value)) {
+	case "1", "true", "on", "yes":
+		return true
+	case "0", "false", "off", "no":
+		return false
+	}
+
+	return defaultValue
+}
+
+// Duration 格式化为 time.Duration，支持 1m30s 形式，纯数字按秒处理
+func (f *Format) Duration(defaultValue time.Duration) time.Duration {
+	if f.Value == "" {
+		return defaultValue
+	}
+
+	if val, err := strconv.ParseInt(f.Value, 10, 64); err == nil {
+		return time.Duration(val) * time.Second
+	}
+
+	val, err := time.ParseDuration(f.Value)
+	if err != nil {
+		return defaultValue
+	}
+
+	return val
+}
+
+// Time 按 layout 格式化为 time.Time，使用本地时区
+func (f *Format) Time(layout string, defaultValue time.Time) time.Time {
+	if f.Value == "" {
+		return defaultValue
+	}
+
+	val, err := time.ParseInLocation(layout, f.Value, time.Local)
+	if err != nil {
+		return defaultValue
+	}
+
+	return val
+}
+
+// Strings 按逗号分隔格式化为 []string，忽略空白项
+func (f *Format) Strings(defaultValue []string) []string {
+	var vals []string
+	for _, item := range strings.Split(f.Value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			vals = append(vals, item)
+		}
+	}
+
+	if len(vals) == 0 {
+		return defaultValue
+	}
+
+	return vals
+}
+
+// Ints 按逗号分隔格式化为 []int，任一项无效时返回默认值
+func (f *Format) Ints(defaultValue []int) []int {
+	items := f.Strings(nil)
+	if len(items) == 0 {
+		return defaultValue
+	}
+
+	vals := make([]int, 0, len(items))
+	for _, item := range items {
+		val, err := strconv.Atoi(item)
+		if err != nil {
+			return defaultValue
+		}
+		vals = append(vals, val)
+	}
+
+	return vals
+}
+
+// Int64s 按逗号分隔格式化为 []int64，任一项无效时返回默认值
+func (f *Format) Int64s(defaultValue []int64) []int64 {
+	items := f.Strings(nil)
+	if len(items) == 0 {
+		return defaultValue
+	}
+
+	vals := make([]int64, 0, len(items))
+	for _, item := range items {
+		val, err := strconv.ParseInt(item, 10, 64)
+		if err != nil {
+			return defaultValue
+		}
+		vals = append(vals, val)
+	}
+
+	return vals
+}
+
+// Float64s 按逗号分隔格式化为 []float64，任一项无效时返回默认值
+func (f *Format) Float64s(defaultValue []float64) []float64 {
+	items := f.Strings(nil)
+	if len(items) == 0 {
+		return defaultValue
+	}
+
+	vals := make([]float64, 0, len(items))
+	for _, item := range items {
+		val, err := strconv.ParseFloat(item, 64)
+		if err != nil {
+			return defaultValue
+		}
+		vals = append(vals, val)
+	}
+
+	return vals
+}
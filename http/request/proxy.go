@@ -0,0 +1,232 @@
+package request
+
+import (
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// forwarded 经可信代理转发的客户端信息
+type forwarded struct {
+	ip    string
+	proto string
+	host  string
+}
+
+// ClientIP 客户端 IP，直接连接的对端为可信代理时从 forwardedHeader 配置的转发头中解析，否则为对端 IP
+func (d *Driver) ClientIP() string {
+	return d.forwarded().ip
+}
+
+// IsTrustedProxy 直接连接的对端是否为可信代理
+func (d *Driver) IsTrustedProxy() bool {
+	return isTrustedProxy(d.Request.RemoteAddr)
+}
+
+// forwarded 解析并缓存转发信息，对端不是可信代理时忽略转发头
+// 仅使用 forwardedHeader 配置的转发头，其它转发头可能由客户端伪造
+func (d *Driver) forwarded() *forwarded {
+	if d.proxy != nil {
+		return d.proxy
+	}
+
+	f := &forwarded{ip: d.Request.RemoteAddr}
+	if host, _, err := net.SplitHostPort(d.Request.RemoteAddr); err == nil {
+		f.ip = host
+	}
+	d.proxy = f
+
+	if !d.IsTrustedProxy() {
+		return f
+	}
+
+	switch getConfig().forwardedHeader {
+	case "Forwarded":
+		d.parseForwardedHeader(f)
+	case "X-Forwarded-For":
+		d.parseXForwardedFor(f)
+	case "X-Real-Ip":
+		if addr, err := netip.ParseAddr(strings.TrimSpace(d.Request.Header.Get("X-Real-Ip"))); err == nil {
+			f.ip = addr.Unmap().String()
+		}
+
+		// 仅有一层代理，取最后一个值
+		f.proto = forwardedValue(d.Request.Header.Values("X-Forwarded-Proto"), 0)
+		f.host = forwardedValue(d.Request.Header.Values("X-Forwarded-Host"), 0)
+	}
+
+	return f
+}
+
+// parseForwardedHeader 解析 RFC 7239 Forwarded，从右向左跳过可信代理，第一个不可信的节点为客户端
+// proto 及 host 取自同一节点
+func (d *Driver) parseForwardedHeader(f *forwarded) {
+	elements := parseForwarded(d.Request.Header.Values("Forwarded"))
+	for i := len(elements) - 1; i >= 0; i-- {
+		ip := parseForwardedNode(elements[i]["for"])
+		if ip == "" {
+			return
+		}
+
+		f.ip = ip
+		f.proto = elements[i]["proto"]
+		f.host = elements[i]["host"]
+
+		if !isTrustedProxy(ip) {
+			return
+		}
+	}
+}
+
+// parseXForwardedFor 解析 X-Forwarded-For，从右向左跳过可信代理，第一个不可信的地址为客户端
+// X-Forwarded-Proto 及 X-Forwarded-Host 取自右侧同一位置的值，即记录客户端地址的代理写入的值，
+// 代理覆盖而非追加这两个头导致该位置不存在时忽略
+func (d *Driver) parseXForwardedFor(f *forwarded) {
+	ips := splitForwarded(d.Request.Header.Values("X-Forwarded-For"))
+
+	// 客户端地址在右侧的位置，0 为最后一个
+	hop := -1
+	for i := len(ips) - 1; i >= 0; i-- {
+		addr, err := netip.ParseAddr(ips[i])
+		if err != nil {
+			break
+		}
+
+		f.ip = addr.Unmap().String()
+		hop = len(ips) - 1 - i
+		if !isTrustedProxy(f.ip) {
+			break
+		}
+	}
+
+	if hop < 0 {
+		return
+	}
+
+	f.proto = forwardedValue(d.Request.Header.Values("X-Forwarded-Proto"), hop)
+	f.host = forwardedValue(d.Request.Header.Values("X-Forwarded-Host"), hop)
+}
+
+// splitForwarded 拆分以 , 分隔的多值转发头
+func splitForwarded(values []string) []string {
+	var items []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			items = append(items, strings.TrimSpace(item))
+		}
+	}
+
+	return items
+}
+
+// forwardedValue 获取多值转发头中从右侧数第 hop 个值，0 为最后一个，不存在时返回空
+func forwardedValue(values []string, hop int) string {
+	items := splitForwarded(values)
+	if hop >= len(items) {
+		return ""
+	}
+
+	return items[len(items)-1-hop]
+}
+
+// isTrustedProxy 地址是否属于可信代理，addr 可带端口
+// unix socket 连接的对端地址为空，配置中包含 unix 时视为可信
+func isTrustedProxy(addr string) bool {
+	c := getConfig()
+
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+
+	ip, err := netip.ParseAddr(addr)
+	if err != nil {
+		return c.trustUnixSocket && (addr == "" || addr == "@")
+	}
+
+	ip = ip.Unmap()
+	for _, prefix := range c.trustedProxies {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseForwarded 解析 Forwarded 头，返回各节点的参数，参数名为小写
+func parseForwarded(values []string) []map[string]string {
+	var elements []map[string]string
+
+	for _, value := range values {
+		for _, element := range strings.Split(value, ",") {
+			params := make(map[string]string)
+			for _, pair := range strings.Split(element, ";") {
+				key, val, ok := strings.Cut(strings.TrimSpace(pair), "=")
+				if !ok {
+					continue
+				}
+				params[strings.ToLower(strings.TrimSpace(key))] = strings.Trim(strings.TrimSpace(val), "\"")
+			}
+			elements = append(elements, params)
+		}
+	}
+
+	return elements
+}
+
+// parseForwardedNode 解析 Forwarded 中 for 参数的 IP，如 192.0.2.60、"[2001:db8::1]:4711"
+// 为 unknown 或混淆标识时返回空
+func parseForwardedNode(node string) string {
+	if strings.HasPrefix(node, "[") {
+		end := strings.Index(node, "]")
+		if end < 0 {
+			return ""
+		}
+		node = node[1:end]
+	} else if strings.Count(node, ":") == 1 {
+		node, _, _ = strings.Cut(node, ":")
+	}
+
+	addr, err := netip.ParseAddr(node)
+	if err != nil {
+		return ""
+	}
+
+	return addr.Unmap().String()
+}
+
+// parseTrustedProxies 解析可信代理，支持 CIDR 及单个 IP，unix 表示信任 unix socket 连接
+func parseTrustedProxies(items []string) ([]netip.Prefix, bool, bool) {
+	var prefixes []netip.Prefix
+	trustUnixSocket := false
+
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		if item == "unix" {
+			trustUnixSocket = true
+			continue
+		}
+
+		if strings.Contains(item, "/") {
+			prefix, err := netip.ParsePrefix(item)
+			if err != nil {
+				return nil, false, false
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+
+		addr, err := netip.ParseAddr(item)
+		if err != nil {
+			return nil, false, false
+		}
+		addr = addr.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+
+	return prefixes, trustUnixSocket, true
+}
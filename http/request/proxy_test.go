@@ -0,0 +1,124 @@
+package request
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestForwarded(t *testing.T) {
+	tests := []struct {
+		name            string
+		forwardedHeader string
+		remoteAddr      string
+		headers         map[string]string
+		wantIP          string
+		wantHost        string
+		wantScheme      string
+	}{
+		{
+			name:       "untrusted peer",
+			remoteAddr: "198.51.100.7:1234",
+			headers:    map[string]string{"X-Forwarded-For": "1.2.3.4", "X-Forwarded-Host": "evil.com"},
+			wantIP:     "198.51.100.7",
+			wantHost:   "example.com",
+			wantScheme: "http",
+		},
+		{
+			name:       "forwarded ignored for xff",
+			remoteAddr: "10.0.0.1:1234",
+			headers:    map[string]string{"Forwarded": "for=1.2.3.4;host=evil.com", "X-Forwarded-For": "203.0.113.9"},
+			wantIP:     "203.0.113.9",
+			wantHost:   "example.com",
+			wantScheme: "http",
+		},
+		{
+			name:       "host from the client hop",
+			remoteAddr: "10.0.0.1:1234",
+			headers: map[string]string{
+				"X-Forwarded-For":   "1.2.3.4, 203.0.113.9",
+				"X-Forwarded-Host":  "evil.com, real.com",
+				"X-Forwarded-Proto": "http, https",
+			},
+			wantIP:     "203.0.113.9",
+			wantHost:   "real.com",
+			wantScheme: "https",
+		},
+		{
+			name:       "skip trusted hops",
+			remoteAddr: "10.0.0.1:1234",
+			headers: map[string]string{
+				"X-Forwarded-For":  "203.0.113.9, 10.0.0.2",
+				"X-Forwarded-Host": "real.com, internal.local",
+			},
+			wantIP:     "203.0.113.9",
+			wantHost:   "real.com",
+			wantScheme: "http",
+		},
+		{
+			name:       "overwritten host shorter than hops",
+			remoteAddr: "10.0.0.1:1234",
+			headers: map[string]string{
+				"X-Forwarded-For":  "203.0.113.9, 10.0.0.2",
+				"X-Forwarded-Host": "evil.com",
+			},
+			wantIP:     "203.0.113.9",
+			wantHost:   "example.com",
+			wantScheme: "http",
+		},
+		{
+			name:            "forwarded header",
+			forwardedHeader: "Forwarded",
+			remoteAddr:      "10.0.0.1:1234",
+			headers: map[string]string{
+				"Forwarded":       "for=1.2.3.4;host=evil.com, for=203.0.113.9;host=real.com;proto=https",
+				"X-Forwarded-For": "5.6.7.8",
+			},
+			wantIP:     "203.0.113.9",
+			wantHost:   "real.com",
+			wantScheme: "https",
+		},
+		{
+			name:            "x-real-ip header",
+			forwardedHeader: "x-real-ip",
+			remoteAddr:      "10.0.0.1:1234",
+			headers:         map[string]string{"X-Real-IP": "203.0.113.9", "X-Forwarded-For": "1.2.3.4"},
+			wantIP:          "203.0.113.9",
+			wantHost:        "example.com",
+			wantScheme:      "http",
+		},
+	}
+
+	defer initConfig()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initConfig()
+			c := map[string]any{"trustedProxies": "10.0.0.0/8"}
+			if tt.forwardedHeader != "" {
+				c["forwardedHeader"] = tt.forwardedHeader
+			}
+			if err := SetConfig(c); err != nil {
+				t.Fatalf("SetConfig() error = %v", err)
+			}
+
+			r := httptest.NewRequest("GET", "http://example.com/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for key, value := range tt.headers {
+				r.Header.Set(key, value)
+			}
+
+			d := &Driver{}
+			d.Init(r)
+
+			if got := d.ClientIP(); got != tt.wantIP {
+				t.Errorf("ClientIP() = %q, want %q", got, tt.wantIP)
+			}
+			if got := d.Host(); got != tt.wantHost {
+				t.Errorf("Host() = %q, want %q", got, tt.wantHost)
+			}
+			if got := d.Scheme(); got != tt.wantScheme {
+				t.Errorf("Scheme() = %q, want %q", got, tt.wantScheme)
+			}
+		})
+	}
+}
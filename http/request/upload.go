@@ -0,0 +1,200 @@
+package request
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/go-nt/nt/util/fs/file"
+)
+
+// isMultipart 是否为 multipart/form-data 请求
+func (d *Driver) isMultipart() bool {
+	mediaType, _, err := mime.ParseMediaType(d.Request.Header.Get("Content-Type"))
+	return err == nil && mediaType == "multipart/form-data"
+}
+
+// ErrMultipartTooLarge multipart 请求体超出 maxMultipartSize 配置
+var ErrMultipartTooLarge = errors.New("request upload error: multipart body is too large")
+
+// parseMultipart 首次访问 POST 数据或上传文件时解析 multipart 表单
+// 请求体超出 maxMultipartSize 配置时返回 ErrMultipartTooLarge
+func (d *Driver) parseMultipart() error {
+	if d.multipartParsed || !d.isMultipart() {
+		return d.multipartErr
+	}
+	d.multipartParsed = true
+
+	c := getConfig()
+	if c.maxMultipartSize > 0 {
+		if d.Request.ContentLength > c.maxMultipartSize {
+			d.multipartErr = ErrMultipartTooLarge
+			return d.multipartErr
+		}
+
+		if d.Request.Body != nil {
+			d.Request.Body = http.MaxBytesReader(nil, d.Request.Body, c.maxMultipartSize)
+		}
+	}
+
+	err := d.Request.ParseMultipartForm(c.maxMemory)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			err = ErrMultipartTooLarge
+		}
+		d.multipartErr = err
+		return err
+	}
+
+	d.dPost = d.Request.PostForm
+	return nil
+}
+
+// File 获取指定名称的上传文件
+func (d *Driver) File(name string) (*multipart.FileHeader, error) {
+	files, err := d.Files(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return files[0], nil
+}
+
+// Files 获取指定名称的所有上传文件
+func (d *Driver) Files(name string) ([]*multipart.FileHeader, error) {
+	if err := d.parseMultipart(); err != nil {
+		return nil, err
+	}
+
+	if d.Request.MultipartForm != nil {
+		if files, ok := d.Request.MultipartForm.File[name]; ok && len(files) > 0 {
+			return files, nil
+		}
+	}
+
+	return nil, http.ErrMissingFile
+}
+
+// SaveFile 校验大小、扩展名及 MIME 类型后将上传文件保存到 dst
+func (d *Driver) SaveFile(fh *multipart.FileHeader, dst string) error {
+	if err := checkFileSize(fh.Size); err != nil {
+		return err
+	}
+
+	if err := checkFileExt(fh.Filename); err != nil {
+		return err
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	src, err := checkFileMime(f)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Save(dst, src)
+	return err
+}
+
+// MultipartReader 以流的方式逐个读取 multipart 表单项，适用于大文件上传，
+// 调用后不能再通过 Post、File 等方法获取表单数据
+func (d *Driver) MultipartReader() (*multipart.Reader, error) {
+	if d.multipartParsed {
+		return nil, errors.New("request upload error: multipart form has already been parsed")
+	}
+	d.multipartParsed = true
+
+	return d.Request.MultipartReader()
+}
+
+// SavePart 校验扩展名及 MIME 类型后将表单项以流的方式保存到 dst，超出大小限制时中止并删除文件
+func (d *Driver) SavePart(part *multipart.Part, dst string) (int64, error) {
+	if err := checkFileExt(part.FileName()); err != nil {
+		return 0, err
+	}
+
+	src, err := checkFileMime(part)
+	if err != nil {
+		return 0, err
+	}
+
+	maxFileSize := getConfig().maxFileSize
+	if maxFileSize > 0 {
+		// 多读取一个字节用于判断是否超出限制
+		src = io.LimitReader(src, maxFileSize+1)
+	}
+
+	n, err := file.Save(dst, src)
+	if err != nil {
+		return n, err
+	}
+
+	if err := checkFileSize(n); err != nil {
+		_ = file.Remove(dst)
+		return n, err
+	}
+
+	return n, nil
+}
+
+// checkFileSize 校验文件大小
+func checkFileSize(size int64) error {
+	maxFileSize := getConfig().maxFileSize
+	if maxFileSize > 0 && size > maxFileSize {
+		return errors.New("request upload error: file size exceeds the limit of " + strconv.FormatInt(maxFileSize, 10) + " bytes")
+	}
+
+	return nil
+}
+
+// checkFileExt 校验文件扩展名
+func checkFileExt(filename string) error {
+	allowedExts := getConfig().allowedExts
+	if len(allowedExts) == 0 {
+		return nil
+	}
+
+	ext := strings.ToLower(filepath.Ext(filename))
+	for _, allowed := range allowedExts {
+		if ext == allowed {
+			return nil
+		}
+	}
+
+	return errors.New("request upload error: file extension(" + ext + ") is not allowed")
+}
+
+// checkFileMime 按文件内容检测并校验 MIME 类型，返回包含已读取部分的完整数据流
+func checkFileMime(r io.Reader) (io.Reader, error) {
+	allowedMimes := getConfig().allowedMimes
+	if len(allowedMimes) == 0 {
+		return r, nil
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	head = head[:n]
+
+	mimeType, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	for _, allowed := range allowedMimes {
+		if mimeType == allowed || (strings.HasSuffix(allowed, "/*") && strings.HasPrefix(mimeType, strings.TrimSuffix(allowed, "*"))) {
+			return io.MultiReader(bytes.NewReader(head), r), nil
+		}
+	}
+
+	return nil, errors.New("request upload error: file type(" + mimeType + ") is not allowed")
+}
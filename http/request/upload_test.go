@@ -0,0 +1,62 @@
+package request
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseMultipartLimit(t *testing.T) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	_ = w.WriteField("name", "a")
+	part, _ := w.CreateFormFile("file", "a.txt")
+	_, _ = part.Write([]byte(strings.Repeat("x", 4096)))
+	_ = w.Close()
+
+	defer initConfig()
+	initConfig()
+	if err := SetConfig(map[string]any{"maxMultipartSize": 1024}); err != nil {
+		t.Fatalf("SetConfig() error = %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		contentLength int64
+	}{
+		{"content length", int64(buf.Len())},
+		{"unknown length", -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/upload", io.NopCloser(bytes.NewReader(buf.Bytes())))
+			r.Header.Set("Content-Type", w.FormDataContentType())
+			r.ContentLength = tt.contentLength
+
+			d := &Driver{}
+			d.Init(r)
+
+			if _, err := d.File("file"); err != ErrMultipartTooLarge {
+				t.Fatalf("File() error = %v, want %v", err, ErrMultipartTooLarge)
+			}
+		})
+	}
+
+	initConfig()
+	r := httptest.NewRequest("POST", "/upload", bytes.NewReader(buf.Bytes()))
+	r.Header.Set("Content-Type", w.FormDataContentType())
+
+	d := &Driver{}
+	d.Init(r)
+
+	if got := d.Post("name", ""); got != "a" {
+		t.Errorf("Post(name) = %q, want %q", got, "a")
+	}
+	if fh, err := d.File("file"); err != nil || fh.Size != 4096 {
+		t.Errorf("File() = %v, %v, want size 4096", fh, err)
+	}
+}
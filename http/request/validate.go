@@ -0,0 +1,376 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// FieldError 单个字段的校验错误
+type FieldError struct {
+	// 字段名，嵌套字段及 map 的键以 . 连接，切片下标以 [i] 表示，如 address.city、items[0].name
+	Field string `json:"field"`
+
+	// 未通过的规则
+	Rule string `json:"rule"`
+
+	// 错误信息
+	Message string `json:"message"`
+}
+
+// ValidationError 校验错误，包含所有未通过校验的字段
+type ValidationError struct {
+	Errors []*FieldError `json:"errors"`
+}
+
+// Error 实现 error 接口
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, fieldError := range e.Errors {
+		messages = append(messages, fieldError.Message)
+	}
+
+	return "request validate error: " + strings.Join(messages, "; ")
+}
+
+// 已编译的正则表达式缓存
+var regexCache sync.Map
+
+// Validate 按 validate 标签校验结构体，如 `validate:"required,min=3,max=20"`
+// 支持的规则：required, min, max, len, regex, email, url, oneof, alpha, alphanum, numeric
+// regex 规则须放在最后，其后的内容均作为正则表达式
+func Validate(ptr any) error {
+	rv := reflect.ValueOf(ptr)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return errors.New("request validate error: param of ptr is nil")
+		}
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var fieldErrors []*FieldError
+	if err := validateStruct(rv, "", &fieldErrors); err != nil {
+		return err
+	}
+
+	if len(fieldErrors) > 0 {
+		return &ValidationError{Errors: fieldErrors}
+	}
+
+	return nil
+}
+
+// validateStruct 校验结构体的各字段，嵌套结构体递归校验
+func validateStruct(rv reflect.Value, prefix string, fieldErrors *[]*FieldError) error {
+	rt := rv.Type()
+
+	for i := 0; i < rv.NumField(); i++ {
+		rtField := rt.Field(i)
+		if !rtField.IsExported() {
+			continue
+		}
+
+		rvField := rv.Field(i)
+
+		// 未指定名称的嵌入结构体，与绑定一致，字段平铺校验
+		if rtField.Anonymous && rtField.Tag.Get("bind") == "" && rvField.Kind() == reflect.Struct {
+			if err := validateStruct(rvField, prefix, fieldErrors); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name := prefix + fieldName(rtField)
+
+		tag := rtField.Tag.Get("validate")
+		if tag != "" && tag != "-" {
+			if err := validateField(rvField, name, tag, fieldErrors); err != nil {
+				return err
+			}
+		}
+
+		if tag == "-" {
+			continue
+		}
+
+		// 递归校验嵌套结构体及结构体切片
+		elem := rvField
+		for elem.Kind() == reflect.Ptr && !elem.IsNil() {
+			elem = elem.Elem()
+		}
+
+		switch elem.Kind() {
+		case reflect.Struct:
+			if elem.Type().PkgPath() == "time" {
+				continue
+			}
+			if err := validateStruct(elem, name+".", fieldErrors); err != nil {
+				return err
+			}
+		case reflect.Slice, reflect.Array:
+			for j := 0; j < elem.Len(); j++ {
+				item := elem.Index(j)
+				for item.Kind() == reflect.Ptr && !item.IsNil() {
+					item = item.Elem()
+				}
+				if item.Kind() == reflect.Struct {
+					if err := validateStruct(item, name+"["+strconv.Itoa(j)+"].", fieldErrors); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// fieldPath 将绑定时使用的 address[city] 形式的名称转为 address.city 形式，数字下标保持 [i]
+func fieldPath(name string) string {
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(name, '[')
+		if start < 0 {
+			b.WriteString(name)
+			return b.String()
+		}
+
+		end := strings.IndexByte(name[start:], ']')
+		if end < 0 {
+			b.WriteString(name)
+			return b.String()
+		}
+		end += start
+
+		b.WriteString(name[:start])
+		key := name[start+1 : end]
+		if _, err := strconv.Atoi(key); err == nil {
+			b.WriteString("[" + key + "]")
+		} else {
+			b.WriteString("." + key)
+		}
+		name = name[end+1:]
+	}
+}
+
+// fieldName 字段名，取 bind 标签，未设置时为字段名，绑定及校验错误均使用该名称
+func fieldName(rtField reflect.StructField) string {
+	if name, _, _ := strings.Cut(rtField.Tag.Get("bind"), ","); name != "" && name != "-" {
+		return name
+	}
+
+	return rtField.Name
+}
+
+// validateField 按规则校验单个字段
+func validateField(rv reflect.Value, name string, tag string, fieldErrors *[]*FieldError) error {
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			break
+		}
+		rv = rv.Elem()
+	}
+
+	empty := !rv.IsValid() || rv.IsZero() || ((rv.Kind() == reflect.Slice || rv.Kind() == reflect.Map) && rv.Len() == 0)
+
+	for _, rule := range splitRules(tag) {
+		ruleName, param, _ := strings.Cut(rule, "=")
+
+		if ruleName == "required" {
+			if empty {
+				*fieldErrors = append(*fieldErrors, &FieldError{Field: name, Rule: ruleName, Message: name + " is required"})
+				return nil
+			}
+			continue
+		}
+
+		// 非必填字段为空时跳过其它规则
+		if empty {
+			return nil
+		}
+
+		message, err := checkRule(rv, name, ruleName, param)
+		if err != nil {
+			return err
+		}
+
+		if message != "" {
+			*fieldErrors = append(*fieldErrors, &FieldError{Field: name, Rule: ruleName, Message: message})
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// splitRules 拆分规则，regex 规则之后的内容不再拆分
+func splitRules(tag string) []string {
+	var rules []string
+	for tag != "" {
+		if strings.HasPrefix(tag, "regex=") {
+			rules = append(rules, tag)
+			break
+		}
+
+		rule, rest, _ := strings.Cut(tag, ",")
+		if rule = strings.TrimSpace(rule); rule != "" {
+			rules = append(rules, rule)
+		}
+		tag = rest
+	}
+
+	return rules
+}
+
+// checkRule 校验单条规则，未通过时返回错误信息
+func checkRule(rv reflect.Value, name string, rule string, param string) (string, error) {
+	switch rule {
+	case "min", "max", "len":
+		limit, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return "", errors.New("request validate error: rule(" + rule + ") of field(" + name + ") has an invalid parameter")
+		}
+
+		size, unit, ok := measure(rv)
+		if !ok {
+			return "", errors.New("request validate error: rule(" + rule + ") does not support field(" + name + ")")
+		}
+
+		switch rule {
+		case "min":
+			if size < limit {
+				return name + " must be at least " + param + unit, nil
+			}
+		case "max":
+			if size > limit {
+				return name + " must be at most " + param + unit, nil
+			}
+		case "len":
+			if size != limit {
+				return name + " must be exactly " + param + unit, nil
+			}
+		}
+
+	case "regex":
+		value, ok := stringValue(rv)
+		if !ok {
+			return "", errors.New("request validate error: rule(regex) does not support field(" + name + ")")
+		}
+
+		re, err := compileRegex(param)
+		if err != nil {
+			return "", errors.New("request validate error: rule(regex) of field(" + name + ") has an invalid expression")
+		}
+
+		if !re.MatchString(value) {
+			return name + " format is invalid", nil
+		}
+
+	case "email":
+		value, _ := stringValue(rv)
+		address, err := mail.ParseAddress(value)
+		if err != nil || address.Address != value {
+			return name + " must be a valid email address", nil
+		}
+
+	case "url":
+		value, _ := stringValue(rv)
+		u, err := url.ParseRequestURI(value)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return name + " must be a valid url", nil
+		}
+
+	case "oneof":
+		value := fmt.Sprint(rv.Interface())
+		options := strings.Fields(param)
+		for _, option := range options {
+			if value == option {
+				return "", nil
+			}
+		}
+		return name + " must be one of [" + strings.Join(options, " ") + "]", nil
+
+	case "alpha", "alphanum", "numeric":
+		value, _ := stringValue(rv)
+		for _, r := range value {
+			valid := false
+			switch rule {
+			case "alpha":
+				valid = unicode.IsLetter(r)
+			case "alphanum":
+				valid = unicode.IsLetter(r) || unicode.IsDigit(r)
+			case "numeric":
+				valid = r >= '0' && r <= '9'
+			}
+			if !valid {
+				switch rule {
+				case "alpha":
+					return name + " must contain only letters", nil
+				case "alphanum":
+					return name + " must contain only letters and numbers", nil
+				default:
+					return name + " must contain only digits", nil
+				}
+			}
+		}
+
+	default:
+		return "", errors.New("request validate error: unknown rule(" + rule + ") of field(" + name + ")")
+	}
+
+	return "", nil
+}
+
+// measure 字符串、切片取长度，数值取值本身
+func measure(rv reflect.Value) (float64, string, bool) {
+	switch rv.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(rv.String())), " characters", true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(rv.Len()), " items", true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), "", true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), "", true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), "", true
+	}
+
+	return 0, "", false
+}
+
+// stringValue 获取字符串值
+func stringValue(rv reflect.Value) (string, bool) {
+	if rv.Kind() == reflect.String {
+		return rv.String(), true
+	}
+
+	return fmt.Sprint(rv.Interface()), false
+}
+
+// compileRegex 编译并缓存正则表达式
+func compileRegex(expr string) (*regexp.Regexp, error) {
+	if re, ok := regexCache.Load(expr); ok {
+		return re.(*regexp.Regexp), nil
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	regexCache.Store(expr, re)
+	return re, nil
+}
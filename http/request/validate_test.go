@@ -0,0 +1,152 @@
+package request
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+)
+
+type validateTestAddress struct {
+	City string `bind:"city" validate:"required"`
+	Zip  string `validate:"len=5,numeric"`
+}
+
+type validateTestItem struct {
+	Name string `bind:"name" validate:"required,alpha"`
+}
+
+type ValidateTestBase struct {
+	Id int `bind:"id" validate:"min=1"`
+}
+
+type validateTestUser struct {
+	ValidateTestBase
+	Name     string               `bind:"name" validate:"required,min=2,max=5"`
+	Age      int                  `json:"age" validate:"min=18,max=120"`
+	Email    string               `bind:"email" validate:"email"`
+	Website  string               `bind:"website" validate:"url"`
+	Role     string               `bind:"role" validate:"oneof=admin user"`
+	Code     string               `bind:"code" validate:"alphanum,regex=^[a-z]+[0-9]+$"`
+	Nickname *string              `bind:"nickname" validate:"required"`
+	Tags     []string             `bind:"tags" validate:"max=2"`
+	Address  *validateTestAddress `bind:"address"`
+	Items    []validateTestItem   `bind:"items"`
+}
+
+func TestValidate(t *testing.T) {
+	nickname := "nick"
+	valid := func() *validateTestUser {
+		return &validateTestUser{
+			ValidateTestBase: ValidateTestBase{Id: 1},
+			Name:             "alice",
+			Age:              30,
+			Email:            "alice@example.com",
+			Website:          "https://example.com",
+			Role:             "admin",
+			Code:             "abc123",
+			Nickname:         &nickname,
+			Tags:             []string{"a"},
+			Address:          &validateTestAddress{City: "x", Zip: "12345"},
+			Items:            []validateTestItem{{Name: "a"}},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		modify    func(u *validateTestUser)
+		wantField string
+		wantRule  string
+	}{
+		{"valid", func(u *validateTestUser) {}, "", ""},
+		{"required", func(u *validateTestUser) { u.Name = "" }, "name", "required"},
+		{"required pointer", func(u *validateTestUser) { u.Nickname = nil }, "nickname", "required"},
+		{"min string", func(u *validateTestUser) { u.Name = "a" }, "name", "min"},
+		{"max string", func(u *validateTestUser) { u.Name = "abcdef" }, "name", "max"},
+		{"min number", func(u *validateTestUser) { u.Age = 17 }, "Age", "min"},
+		{"max slice", func(u *validateTestUser) { u.Tags = []string{"a", "b", "c"} }, "tags", "max"},
+		{"email", func(u *validateTestUser) { u.Email = "Alice <alice@example.com>" }, "email", "email"},
+		{"url", func(u *validateTestUser) { u.Website = "example.com" }, "website", "url"},
+		{"oneof", func(u *validateTestUser) { u.Role = "root" }, "role", "oneof"},
+		{"alphanum", func(u *validateTestUser) { u.Code = "abc-123" }, "code", "alphanum"},
+		{"regex", func(u *validateTestUser) { u.Code = "123abc" }, "code", "regex"},
+		{"embedded", func(u *validateTestUser) { u.Id = -1 }, "id", "min"},
+		{"nested required", func(u *validateTestUser) { u.Address.City = "" }, "address.city", "required"},
+		{"nested len", func(u *validateTestUser) { u.Address.Zip = "1234" }, "address.Zip", "len"},
+		{"nested numeric", func(u *validateTestUser) { u.Address.Zip = "1234a" }, "address.Zip", "numeric"},
+		{"slice item", func(u *validateTestUser) { u.Items = append(u.Items, validateTestItem{Name: "b2"}) }, "items[1].name", "alpha"},
+		{"optional empty", func(u *validateTestUser) { u.Email = ""; u.Website = ""; u.Address = nil }, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := valid()
+			tt.modify(u)
+
+			err := Validate(u)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+
+			var validationError *ValidationError
+			if !errors.As(err, &validationError) {
+				t.Fatalf("Validate() error = %v, want *ValidationError", err)
+			}
+			if len(validationError.Errors) != 1 {
+				t.Fatalf("Validate() errors = %v, want 1 error", err)
+			}
+			if got := validationError.Errors[0]; got.Field != tt.wantField || got.Rule != tt.wantRule {
+				t.Errorf("Validate() = %s/%s, want %s/%s", got.Field, got.Rule, tt.wantField, tt.wantRule)
+			}
+		})
+	}
+}
+
+func TestValidateInvalidRule(t *testing.T) {
+	var v struct {
+		Name string `validate:"min=x"`
+	}
+	v.Name = "a"
+
+	var validationError *ValidationError
+	if err := Validate(&v); err == nil || errors.As(err, &validationError) {
+		t.Errorf("Validate() error = %v, want configuration error", err)
+	}
+}
+
+func TestBindAndValidateFieldNames(t *testing.T) {
+	type address struct {
+		Zip int `json:"zip" validate:"required"`
+	}
+	type user struct {
+		Age     int     `json:"age" validate:"required"`
+		Address address `json:"address"`
+	}
+
+	r := httptest.NewRequest("GET", "/?Age=x&Address[Zip]=y", nil)
+	d := &Driver{}
+	d.Init(r)
+	d.SetStrictBind(true)
+
+	var u user
+	var bindError *ValidationError
+	if err := d.GetBind(&u); !errors.As(err, &bindError) {
+		t.Fatalf("GetBind() error = %v, want *ValidationError", err)
+	}
+
+	var validateError *ValidationError
+	if err := Validate(&u); !errors.As(err, &validateError) {
+		t.Fatalf("Validate() error = %v, want *ValidationError", err)
+	}
+
+	if len(bindError.Errors) != 2 || len(validateError.Errors) != 2 {
+		t.Fatalf("errors = %v, %v, want 2 each", bindError, validateError)
+	}
+	for i := range bindError.Errors {
+		if bindError.Errors[i].Field != validateError.Errors[i].Field {
+			t.Errorf("bind field = %q, validate field = %q, want equal", bindError.Errors[i].Field, validateError.Errors[i].Field)
+		}
+	}
+}
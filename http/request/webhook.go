@@ -0,0 +1,202 @@
+package request
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrWebhookSignature 签名缺失或不匹配
+var ErrWebhookSignature = errors.New("request webhook error: signature is invalid")
+
+// ErrWebhookTimestamp 时间戳缺失、无效或超出容差
+var ErrWebhookTimestamp = errors.New("request webhook error: timestamp is invalid or expired")
+
+// WebhookConfig webhook 签名校验配置
+type WebhookConfig struct {
+	// 签名密钥
+	Secret string
+
+	// 签名所在的请求头
+	Header string
+
+	// 摘要算法 sha256 | sha1，为空时使用 sha256
+	Algorithm string
+
+	// 签名编码 hex | base64，为空时使用 hex
+	Encoding string
+
+	// 签名前缀，如 sha256=
+	Prefix string
+
+	// 是否为 t=...,v1=... 形式的带时间戳签名，此时签名内容为 "时间戳.请求体"
+	Timestamped bool
+
+	// 带时间戳签名中签名的键，为空时使用 v1，可有多个同名签名，任一匹配即通过
+	SignatureKey string
+
+	// 时间戳所在的请求头，签名内容为 "时间戳.请求体"
+	TimestampHeader string
+
+	// 时间戳与当前时间的最大差值，用于防止重放，0-不校验
+	Tolerance time.Duration
+
+	// 自定义签名内容，为空时有时间戳为 "时间戳.请求体"，否则为请求体
+	Payload func(timestamp string, body []byte) []byte
+}
+
+// WebhookSha256 sha256=<hex> 形式的签名配置，如 GitHub 的 X-Hub-Signature-256
+func WebhookSha256(secret string, header string) WebhookConfig {
+	return WebhookConfig{
+		Secret:    secret,
+		Header:    header,
+		Algorithm: "sha256",
+		Encoding:  "hex",
+		Prefix:    "sha256=",
+	}
+}
+
+// WebhookSha1 sha1=<hex> 形式的签名配置，如 GitHub 的 X-Hub-Signature
+func WebhookSha1(secret string, header string) WebhookConfig {
+	return WebhookConfig{
+		Secret:    secret,
+		Header:    header,
+		Algorithm: "sha1",
+		Encoding:  "hex",
+		Prefix:    "sha1=",
+	}
+}
+
+// WebhookTimestamped t=<时间戳>,v1=<hex> 形式的签名配置，如 Stripe 的 Stripe-Signature
+// 签名内容为 "时间戳.请求体"，tolerance 为时间戳容差
+func WebhookTimestamped(secret string, header string, tolerance time.Duration) WebhookConfig {
+	return WebhookConfig{
+		Secret:       secret,
+		Header:       header,
+		Algorithm:    "sha256",
+		Encoding:     "hex",
+		Timestamped:  true,
+		SignatureKey: "v1",
+		Tolerance:    tolerance,
+	}
+}
+
+// VerifyWebhook 校验 webhook 签名，签名基于原始请求体计算，校验后请求体仍可读取及绑定
+func (d *Driver) VerifyWebhook(config WebhookConfig) error {
+	if config.Secret == "" || config.Header == "" {
+		return errors.New("request webhook error: secret and header are required")
+	}
+
+	var newHash func() hash.Hash
+	switch strings.ToLower(config.Algorithm) {
+	case "", "sha256":
+		newHash = sha256.New
+	case "sha1":
+		newHash = sha1.New
+	default:
+		return errors.New("request webhook error: algorithm(" + config.Algorithm + ") is not supported")
+	}
+
+	value := d.Header(config.Header, "")
+	if value == "" {
+		return ErrWebhookSignature
+	}
+
+	timestamp := ""
+	var signatures []string
+
+	if config.Timestamped {
+		signatureKey := config.SignatureKey
+		if signatureKey == "" {
+			signatureKey = "v1"
+		}
+
+		for _, pair := range strings.Split(value, ",") {
+			key, val, ok := strings.Cut(strings.TrimSpace(pair), "=")
+			if !ok {
+				continue
+			}
+
+			switch key {
+			case "t":
+				timestamp = val
+			case signatureKey:
+				signatures = append(signatures, val)
+			}
+		}
+	} else {
+		signature, ok := strings.CutPrefix(strings.TrimSpace(value), config.Prefix)
+		if !ok {
+			return ErrWebhookSignature
+		}
+		signatures = append(signatures, signature)
+	}
+
+	if config.TimestampHeader != "" {
+		timestamp = d.Header(config.TimestampHeader, "")
+	}
+
+	if config.Tolerance > 0 || config.Timestamped {
+		if err := checkWebhookTimestamp(timestamp, config.Tolerance); err != nil {
+			return err
+		}
+	}
+
+	body, err := d.ReadBody()
+	if err != nil {
+		return err
+	}
+
+	var payload []byte
+	switch {
+	case config.Payload != nil:
+		payload = config.Payload(timestamp, body)
+	case timestamp != "":
+		payload = append([]byte(timestamp+"."), body...)
+	default:
+		payload = body
+	}
+
+	mac := hmac.New(newHash, []byte(config.Secret))
+	mac.Write(payload)
+	expected := mac.Sum(nil)
+
+	for _, signature := range signatures {
+		var actual []byte
+		if strings.ToLower(config.Encoding) == "base64" {
+			actual, err = base64.StdEncoding.DecodeString(signature)
+		} else {
+			actual, err = hex.DecodeString(signature)
+		}
+
+		if err == nil && hmac.Equal(actual, expected) {
+			return nil
+		}
+	}
+
+	return ErrWebhookSignature
+}
+
+// checkWebhookTimestamp 校验秒级时间戳，tolerance 为 0 时仅校验格式
+func checkWebhookTimestamp(timestamp string, tolerance time.Duration) error {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrWebhookTimestamp
+	}
+
+	if tolerance > 0 {
+		diff := time.Since(time.Unix(seconds, 0))
+		if diff > tolerance || diff < -tolerance {
+			return ErrWebhookTimestamp
+		}
+	}
+
+	return nil
+}
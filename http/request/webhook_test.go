@@ -0,0 +1,94 @@
+package request
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// slackWebhook Slack 形式的签名配置，签名内容为 "v0:时间戳:请求体"
+func slackWebhook(secret string) WebhookConfig {
+	return WebhookConfig{
+		Secret:          secret,
+		Header:          "X-Slack-Signature",
+		Prefix:          "v0=",
+		TimestampHeader: "X-Slack-Request-Timestamp",
+		Tolerance:       5 * time.Minute,
+		Payload: func(timestamp string, body []byte) []byte {
+			return []byte("v0:" + timestamp + ":" + string(body))
+		},
+	}
+}
+
+func TestVerifyWebhookFormEncoded(t *testing.T) {
+	const secret = "8f742231b10e8888abcd99yyyzzz85a5"
+	body := "token=xyzz0WbapA4vBCDEFasx0q6G&team_id=T1DC2JH3J&command=%2Fweather&text=94070"
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + timestamp + ":" + body))
+	signature := "v0=" + hex.EncodeToString(mac.Sum(nil))
+
+	tests := []struct {
+		name      string
+		signature string
+		wantErr   error
+	}{
+		{"valid", signature, nil},
+		{"tampered", "v0=" + strings.Repeat("0", 64), ErrWebhookSignature},
+		{"missing prefix", strings.TrimPrefix(signature, "v0="), ErrWebhookSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/slack/command", strings.NewReader(body))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			r.Header.Set("X-Slack-Signature", tt.signature)
+			r.Header.Set("X-Slack-Request-Timestamp", timestamp)
+
+			d := &Driver{}
+			d.Init(r)
+
+			if err := d.VerifyWebhook(slackWebhook(secret)); err != tt.wantErr {
+				t.Fatalf("VerifyWebhook() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got := d.Post("command", ""); got != "/weather" {
+				t.Errorf("Post(command) = %q, want %q", got, "/weather")
+			}
+		})
+	}
+}
+
+func TestVerifyWebhookAfterPost(t *testing.T) {
+	const secret = "secret"
+	body := "a=1&b=2"
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+
+	r := httptest.NewRequest("POST", "/hook", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	d := &Driver{}
+	d.Init(r)
+
+	// 先读取表单数据，签名仍基于完整的原始请求体
+	if got := d.Post("a", ""); got != "1" {
+		t.Fatalf("Post(a) = %q, want %q", got, "1")
+	}
+
+	if err := d.VerifyWebhook(WebhookSha256(secret, "X-Hub-Signature-256")); err != nil {
+		t.Fatalf("VerifyWebhook() error = %v", err)
+	}
+
+	if got := d.Body(""); got != body {
+		t.Errorf("Body() = %q, want %q", got, body)
+	}
+}
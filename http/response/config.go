@@ -0,0 +1,229 @@
+package response
+
+import (
+	"errors"
+
+	"github.com/go-ini/ini"
+)
+
+var config *Config
+
+type Config struct {
+	// 统一响应结构中状态码的字段名
+	envelopeCodeKey string
+
+	// 统一响应结构中提示信息的字段名
+	envelopeMessageKey string
+
+	// 统一响应结构中数据的字段名
+	envelopeDataKey string
+
+	// 成功时的状态码
+	envelopeSuccessCode int
+
+	// 成功时的提示信息
+	envelopeSuccessMessage string
+
+	// 模板根目录
+	viewRoot string
+
+	// 模板文件扩展名
+	viewExt string
+
+	// 默认布局模板，相对模板根目录，为空时不使用布局
+	viewLayout string
+
+	// 公共片段模板目录，相对模板根目录，其中的模板可在所有模板中引用
+	viewPartials string
+
+	// 是否每次渲染时重新解析模板，开发环境使用，否则缓存解析结果
+	viewReload bool
+}
+
+// initConfig 初始化配置
+func initConfig() {
+	config = &Config{
+		envelopeCodeKey:        "code",
+		envelopeMessageKey:     "message",
+		envelopeDataKey:        "data",
+		envelopeSuccessCode:    0,
+		envelopeSuccessMessage: "success",
+		viewRoot:               "view",
+		viewExt:                ".html",
+		viewLayout:             "",
+		viewPartials:           "partials",
+		viewReload:             false,
+	}
+}
+
+// getConfig 获取配置，未设置时使用默认配置
+func getConfig() *Config {
+	if config == nil {
+		initConfig()
+	}
+
+	return config
+}
+
+// SetConfig 参数配置
+func SetConfig(c map[string]any) error {
+	if config == nil {
+		initConfig()
+	}
+
+	for key, value := range c {
+		switch key {
+		case "envelopeCodeKey", "envelope_code_key":
+			if t, ok := value.(string); ok && t != "" {
+				config.envelopeCodeKey = t
+			} else {
+				return errors.New("response config parameter(envelope_code_key) is not a valid value")
+			}
+		case "envelopeMessageKey", "envelope_message_key":
+			if t, ok := value.(string); ok && t != "" {
+				config.envelopeMessageKey = t
+			} else {
+				return errors.New("response config parameter(envelope_message_key) is not a valid value")
+			}
+		case "envelopeDataKey", "envelope_data_key":
+			if t, ok := value.(string); ok && t != "" {
+				config.envelopeDataKey = t
+			} else {
+				return errors.New("response config parameter(envelope_data_key) is not a valid value")
+			}
+		case "envelopeSuccessCode", "envelope_success_code":
+			if t, ok := value.(int); ok {
+				config.envelopeSuccessCode = t
+			} else {
+				return errors.New("response config parameter(envelope_success_code) is not a valid value")
+			}
+		case "envelopeSuccessMessage", "envelope_success_message":
+			if t, ok := value.(string); ok {
+				config.envelopeSuccessMessage = t
+			} else {
+				return errors.New("response config parameter(envelope_success_message) is not a valid value")
+			}
+		case "viewRoot", "view_root":
+			if t, ok := value.(string); ok && t != "" {
+				config.viewRoot = t
+			} else {
+				return errors.New("response config parameter(view_root) is not a valid value")
+			}
+		case "viewExt", "view_ext":
+			if t, ok := value.(string); ok {
+				config.viewExt = t
+			} else {
+				return errors.New("response config parameter(view_ext) is not a valid value")
+			}
+		case "viewLayout", "view_layout":
+			if t, ok := value.(string); ok {
+				config.viewLayout = t
+			} else {
+				return errors.New("response config parameter(view_layout) is not a valid value")
+			}
+		case "viewPartials", "view_partials":
+			if t, ok := value.(string); ok {
+				config.viewPartials = t
+			} else {
+				return errors.New("response config parameter(view_partials) is not a valid value")
+			}
+		case "viewReload", "view_reload":
+			if t, ok := value.(bool); ok {
+				config.viewReload = t
+			} else {
+				return errors.New("response config parameter(view_reload) is not a valid value")
+			}
+		}
+	}
+
+	clearViewCache()
+
+	return nil
+}
+
+// SetIniConfig 设置 ini 配置
+func SetIniConfig(section *ini.Section) error {
+	if config == nil {
+		initConfig()
+	}
+
+	configKeyEnvelopeCodeKey, err := section.GetKey("envelopeCodeKey")
+	if err == nil {
+		if t := configKeyEnvelopeCodeKey.String(); t != "" {
+			config.envelopeCodeKey = t
+		} else {
+			return errors.New("response config parameter(envelopeCodeKey) is not a valid value")
+		}
+	}
+
+	configKeyEnvelopeMessageKey, err := section.GetKey("envelopeMessageKey")
+	if err == nil {
+		if t := configKeyEnvelopeMessageKey.String(); t != "" {
+			config.envelopeMessageKey = t
+		} else {
+			return errors.New("response config parameter(envelopeMessageKey) is not a valid value")
+		}
+	}
+
+	configKeyEnvelopeDataKey, err := section.GetKey("envelopeDataKey")
+	if err == nil {
+		if t := configKeyEnvelopeDataKey.String(); t != "" {
+			config.envelopeDataKey = t
+		} else {
+			return errors.New("response config parameter(envelopeDataKey) is not a valid value")
+		}
+	}
+
+	configKeyEnvelopeSuccessCode, err := section.GetKey("envelopeSuccessCode")
+	if err == nil {
+		t, err := configKeyEnvelopeSuccessCode.Int()
+		if err == nil {
+			config.envelopeSuccessCode = t
+		} else {
+			return errors.New("response config parameter(envelopeSuccessCode) is not a valid value")
+		}
+	}
+
+	configKeyEnvelopeSuccessMessage, err := section.GetKey("envelopeSuccessMessage")
+	if err == nil {
+		config.envelopeSuccessMessage = configKeyEnvelopeSuccessMessage.String()
+	}
+
+	configKeyViewRoot, err := section.GetKey("viewRoot")
+	if err == nil {
+		if t := configKeyViewRoot.String(); t != "" {
+			config.viewRoot = t
+		} else {
+			return errors.New("response config parameter(viewRoot) is not a valid value")
+		}
+	}
+
+	configKeyViewExt, err := section.GetKey("viewExt")
+	if err == nil {
+		config.viewExt = configKeyViewExt.String()
+	}
+
+	configKeyViewLayout, err := section.GetKey("viewLayout")
+	if err == nil {
+		config.viewLayout = configKeyViewLayout.String()
+	}
+
+	configKeyViewPartials, err := section.GetKey("viewPartials")
+	if err == nil {
+		config.viewPartials = configKeyViewPartials.String()
+	}
+
+	configKeyViewReload, err := section.GetKey("viewReload")
+	if err == nil {
+		t, err := configKeyViewReload.Bool()
+		if err == nil {
+			config.viewReload = t
+		} else {
+			return errors.New("response config parameter(viewReload) is not a valid value")
+		}
+	}
+
+	clearViewCache()
+
+	return nil
+}
@@ -9,15 +9,50 @@ import (
 
 type Driver struct {
 	http.ResponseWriter
-	data map[string]any
+	data   map[string]any
+	writer *writer
+
+	// 当前请求，用于内容协商等
+	request *http.Request
 }
 
 // Init 初始化
 func (d *Driver) Init(rw http.ResponseWriter) {
-	d.ResponseWriter = rw
+	d.writer = &writer{ResponseWriter: rw}
+	d.ResponseWriter = d.writer
 	d.data = make(map[string]any)
 }
 
+// SetRequest 设置当前请求
+func (d *Driver) SetRequest(r *http.Request) {
+	d.request = r
+}
+
+// StatusCode 已输出的状态码，尚未输出时为 200
+func (d *Driver) StatusCode() int {
+	if d.writer == nil || d.writer.status == 0 {
+		return http.StatusOK
+	}
+	return d.writer.status
+}
+
+// Size 已输出的响应体字节数
+func (d *Driver) Size() int64 {
+	if d.writer == nil {
+		return 0
+	}
+	return d.writer.size
+}
+
+// Written 是否已输出状态码或内容
+// 中间件替换的 ResponseWriter 实现 Written() bool 时，其缓冲中尚未输出的内容同样视为已输出
+func (d *Driver) Written() bool {
+	if w, ok := d.ResponseWriter.(interface{ Written() bool }); ok && w.Written() {
+		return true
+	}
+	return d.writer != nil && d.writer.status != 0
+}
+
 // Header 输出头你息
 func (d *Driver) Header(name string, value string) {
 	d.ResponseWriter.Header().Set(name, value)
@@ -0,0 +1,107 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// Status 输出状态码，须在设置响应头之后、输出内容之前调用
+func (d *Driver) Status(code int) {
+	d.ResponseWriter.WriteHeader(code)
+}
+
+// NoContent 输出 204 状态码
+func (d *Driver) NoContent() {
+	d.ResponseWriter.WriteHeader(http.StatusNoContent)
+}
+
+// Redirect 重定向，code 不是 3xx 状态码时使用 302
+func (d *Driver) Redirect(url string, code int) {
+	if code < http.StatusMultipleChoices || code > http.StatusPermanentRedirect {
+		code = http.StatusFound
+	}
+
+	if d.request == nil {
+		d.ResponseWriter.Header().Set("Location", url)
+		d.ResponseWriter.WriteHeader(code)
+		return
+	}
+
+	http.Redirect(d.ResponseWriter, d.request, url, code)
+}
+
+// JSON 以指定状态码输出任意数据的 JSON
+func (d *Driver) JSON(code int, v any) error {
+	content, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	d.ResponseWriter.Header().Set("Content-Type", "application/json; charset=utf-8")
+	d.ResponseWriter.WriteHeader(code)
+	_, err = d.ResponseWriter.Write(content)
+	return err
+}
+
+// File 输出文件，支持 Range 及 If-Modified-Since 等条件请求
+func (d *Driver) File(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fInfo, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	if fInfo.IsDir() {
+		return errors.New("response file error: " + path + " is a directory")
+	}
+
+	r := d.request
+	if r == nil {
+		r = &http.Request{Method: http.MethodGet, Header: make(http.Header)}
+	}
+
+	http.ServeContent(d.ResponseWriter, r, fInfo.Name(), fInfo.ModTime(), f)
+	return nil
+}
+
+// Attachment 以附件形式输出文件供下载，filename 为空时使用文件名
+func (d *Driver) Attachment(path string, filename string) error {
+	if filename == "" {
+		filename = filepath.Base(path)
+	}
+
+	d.ResponseWriter.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	return d.File(path)
+}
+
+// Envelope 输出统一结构的 JSON，如 {"code": 0, "message": "success", "data": {}}
+// 字段名可通过 envelopeCodeKey、envelopeMessageKey、envelopeDataKey 配置
+func (d *Driver) Envelope(code int, message string, data any) error {
+	c := getConfig()
+
+	return d.JSON(http.StatusOK, map[string]any{
+		c.envelopeCodeKey:    code,
+		c.envelopeMessageKey: message,
+		c.envelopeDataKey:    data,
+	})
+}
+
+// Success 输出成功的统一结构，状态码及提示信息可通过 envelopeSuccessCode、envelopeSuccessMessage 配置
+func (d *Driver) Success(data any) error {
+	c := getConfig()
+	return d.Envelope(c.envelopeSuccessCode, c.envelopeSuccessMessage, data)
+}
+
+// Fail 输出失败的统一结构
+func (d *Driver) Fail(code int, message string) error {
+	return d.Envelope(code, message, nil)
+}
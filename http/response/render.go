@@ -0,0 +1,39 @@
+package response
+
+import (
+	"strings"
+
+	"github.com/go-nt/nt/http/codec"
+)
+
+// Render 按请求的 Accept 头从 codec 中选择编码器输出，如 JSON、XML、YAML、MessagePack
+// Accept 为空或无匹配的编码器时输出 JSON
+func (d *Driver) Render(v any) error {
+	accept := ""
+	if d.request != nil {
+		accept = d.request.Header.Get("Accept")
+	}
+
+	mimeType, c, ok := codec.Negotiate(accept)
+	if !ok {
+		mimeType, c, _ = codec.Negotiate("")
+	}
+
+	content, err := c.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	header := d.ResponseWriter.Header()
+	header.Add("Vary", "Accept")
+	if header.Get("Content-Type") == "" {
+		if strings.HasPrefix(mimeType, "text/") || strings.Contains(mimeType, "json") || strings.Contains(mimeType, "xml") || strings.Contains(mimeType, "yaml") {
+			header.Set("Content-Type", mimeType+"; charset=utf-8")
+		} else {
+			header.Set("Content-Type", mimeType)
+		}
+	}
+
+	_, err = d.ResponseWriter.Write(content)
+	return err
+}
@@ -0,0 +1,191 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Event 服务端推送事件
+type Event struct {
+	// 事件 ID，客户端重连时通过 Last-Event-ID 请求头带回
+	Id string
+
+	// 事件类型，为空时客户端按 message 处理
+	Event string
+
+	// 事件数据，string / []byte 原样输出，其它类型输出 JSON
+	Data any
+
+	// 客户端重连间隔，0-不设置
+	Retry time.Duration
+}
+
+// EventStream 服务端推送事件流（Server-Sent Events）
+type EventStream struct {
+	rw  http.ResponseWriter
+	rc  *http.ResponseController
+	ctx context.Context
+
+	// 客户端重连时带回的最后一个事件 ID
+	lastEventId string
+
+	mu     sync.Mutex
+	closed bool
+	stop   chan struct{}
+}
+
+// EventStream 开启服务端推送事件流，通过当前请求检测客户端断开及读取 Last-Event-ID
+func (d *Driver) EventStream() (*EventStream, error) {
+	rc := http.NewResponseController(d.ResponseWriter)
+
+	// 事件流为长连接，取消写超时
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	header := d.ResponseWriter.Header()
+	header.Set("Content-Type", "text/event-stream; charset=utf-8")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	header.Del("Content-Length")
+
+	d.ResponseWriter.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		return nil, errors.New("response event stream error: streaming is not supported")
+	}
+
+	s := &EventStream{
+		rw:   d.ResponseWriter,
+		rc:   rc,
+		ctx:  context.Background(),
+		stop: make(chan struct{}),
+	}
+	if d.request != nil {
+		s.ctx = d.request.Context()
+		s.lastEventId = d.request.Header.Get("Last-Event-ID")
+	}
+
+	return s, nil
+}
+
+// LastEventId 客户端重连时带回的最后一个事件 ID，首次连接时为空
+func (s *EventStream) LastEventId() string {
+	return s.lastEventId
+}
+
+// Done 客户端断开时关闭
+func (s *EventStream) Done() <-chan struct{} {
+	return s.ctx.Done()
+}
+
+// Send 发送事件
+func (s *EventStream) Send(event *Event) error {
+	var b strings.Builder
+
+	if event.Id != "" {
+		b.WriteString("id: " + singleLine(event.Id) + "\n")
+	}
+	if event.Event != "" {
+		b.WriteString("event: " + singleLine(event.Event) + "\n")
+	}
+	if event.Retry > 0 {
+		b.WriteString("retry: " + strconv.FormatInt(event.Retry.Milliseconds(), 10) + "\n")
+	}
+
+	var data string
+	switch t := event.Data.(type) {
+	case nil:
+	case string:
+		data = t
+	case []byte:
+		data = string(t)
+	default:
+		content, err := json.Marshal(t)
+		if err != nil {
+			return err
+		}
+		data = string(content)
+	}
+
+	// \r\n、\r 均为换行，统一后按行输出，防止注入额外字段
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	data = strings.ReplaceAll(data, "\r", "\n")
+	for _, line := range strings.Split(data, "\n") {
+		b.WriteString("data: " + line + "\n")
+	}
+	b.WriteString("\n")
+
+	return s.write(b.String())
+}
+
+// SendData 发送仅包含数据的事件
+func (s *EventStream) SendData(data any) error {
+	return s.Send(&Event{Data: data})
+}
+
+// Comment 发送注释行，客户端会忽略，可用于保持连接
+func (s *EventStream) Comment(text string) error {
+	return s.write(": " + singleLine(text) + "\n\n")
+}
+
+// Heartbeat 按间隔发送心跳注释，直到客户端断开或调用 Close
+func (s *EventStream) Heartbeat(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := s.Comment("heartbeat"); err != nil {
+					return
+				}
+			case <-s.ctx.Done():
+				return
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Close 结束事件流，停止心跳，处理器返回前应调用
+func (s *EventStream) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.closed {
+		s.closed = true
+		close(s.stop)
+	}
+}
+
+// write 写入并立即刷新
+func (s *EventStream) write(content string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return errors.New("response event stream error: stream is closed")
+	}
+
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+
+	if _, err := s.rw.Write([]byte(content)); err != nil {
+		return err
+	}
+
+	return s.rc.Flush()
+}
+
+// singleLine 去除换行，防止注入额外字段
+func singleLine(value string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+}
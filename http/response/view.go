@@ -0,0 +1,198 @@
+package response
+
+import (
+	"bytes"
+	"errors"
+	"html/template"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+var (
+	viewMu sync.RWMutex
+
+	// 已解析的模板缓存，键为布局及模板名称
+	viewCache = make(map[string]*template.Template)
+
+	// 模板自定义函数
+	viewFuncs = make(template.FuncMap)
+)
+
+// AddFunc 注册模板自定义函数，须在渲染前注册
+func AddFunc(name string, fn any) {
+	viewMu.Lock()
+	defer viewMu.Unlock()
+
+	viewFuncs[name] = fn
+	viewCache = make(map[string]*template.Template)
+}
+
+// AddFuncs 批量注册模板自定义函数，须在渲染前注册
+func AddFuncs(funcs template.FuncMap) {
+	viewMu.Lock()
+	defer viewMu.Unlock()
+
+	for name, fn := range funcs {
+		viewFuncs[name] = fn
+	}
+	viewCache = make(map[string]*template.Template)
+}
+
+// clearViewCache 清空模板缓存
+func clearViewCache() {
+	viewMu.Lock()
+	defer viewMu.Unlock()
+
+	viewCache = make(map[string]*template.Template)
+}
+
+// View 使用默认布局渲染模板并输出，names 为相对模板根目录的模板名称，可省略扩展名
+// 第一个模板为页面，布局中以 {{template "content" .}} 引用页面内容
+// 公共片段目录中的模板以相对路径命名，如 {{template "partials/header" .}}
+// 与 Display 不同，模板经缓存且渲染出错时返回错误
+func (d *Driver) View(names ...string) error {
+	return d.ViewLayout(getConfig().viewLayout, names...)
+}
+
+// ViewLayout 使用指定布局渲染模板并输出，layout 为空时不使用布局
+func (d *Driver) ViewLayout(layout string, names ...string) error {
+	if len(names) == 0 {
+		return errors.New("response view error: template name is empty")
+	}
+
+	tmpl, err := loadView(layout, names)
+	if err != nil {
+		return err
+	}
+
+	entry := viewName(names[0])
+	if layout != "" {
+		entry = viewName(layout)
+	}
+
+	// 先渲染到缓冲区，出错时不输出不完整的内容
+	var buf bytes.Buffer
+	if err = tmpl.ExecuteTemplate(&buf, entry, d.data); err != nil {
+		return err
+	}
+
+	header := d.ResponseWriter.Header()
+	if header.Get("Content-Type") == "" {
+		header.Set("Content-Type", "text/html; charset=utf-8")
+	}
+
+	_, err = d.ResponseWriter.Write(buf.Bytes())
+	return err
+}
+
+// loadView 获取已解析的模板，开发环境下每次重新解析
+func loadView(layout string, names []string) (*template.Template, error) {
+	c := getConfig()
+	key := layout + "|" + strings.Join(names, ",")
+
+	if !c.viewReload {
+		viewMu.RLock()
+		tmpl, ok := viewCache[key]
+		viewMu.RUnlock()
+
+		if ok {
+			return tmpl, nil
+		}
+	}
+
+	tmpl, err := parseView(c, layout, names)
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.viewReload {
+		viewMu.Lock()
+		viewCache[key] = tmpl
+		viewMu.Unlock()
+	}
+
+	return tmpl, nil
+}
+
+// parseView 依次解析公共片段、布局及页面模板
+func parseView(c *Config, layout string, names []string) (*template.Template, error) {
+	viewMu.RLock()
+	tmpl := template.New("").Funcs(viewFuncs)
+	viewMu.RUnlock()
+
+	if c.viewPartials != "" {
+		partialsDir := filepath.Join(c.viewRoot, filepath.FromSlash(c.viewPartials))
+		err := filepath.WalkDir(partialsDir, func(p string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if entry.IsDir() || (c.viewExt != "" && filepath.Ext(p) != c.viewExt) {
+				return nil
+			}
+
+			rel, err := filepath.Rel(c.viewRoot, p)
+			if err != nil {
+				return err
+			}
+
+			return parseViewFile(tmpl, c, filepath.ToSlash(rel))
+		})
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	if layout != "" {
+		if err := parseViewFile(tmpl, c, layout); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, name := range names {
+		if err := parseViewFile(tmpl, c, name); err != nil {
+			return nil, err
+		}
+	}
+
+	// 页面未定义 content 时，以页面本身作为布局中的 content
+	if tmpl.Lookup("content") == nil {
+		page := tmpl.Lookup(viewName(names[0]))
+		if _, err := tmpl.AddParseTree("content", page.Tree); err != nil {
+			return nil, err
+		}
+	}
+
+	return tmpl, nil
+}
+
+// parseViewFile 解析模板文件，模板以相对模板根目录且不含扩展名的路径命名
+func parseViewFile(tmpl *template.Template, c *Config, name string) error {
+	name = viewName(name)
+	if name == "" {
+		return errors.New("response view error: template name(" + name + ") is not valid")
+	}
+
+	content, err := os.ReadFile(filepath.Join(c.viewRoot, filepath.FromSlash(name)+c.viewExt))
+	if err != nil {
+		return err
+	}
+
+	_, err = tmpl.New(name).Parse(string(content))
+	return err
+}
+
+// viewName 统一模板名称，去除扩展名及开头的 /
+func viewName(name string) string {
+	name = path.Clean("/" + filepath.ToSlash(name))[1:]
+
+	if ext := getConfig().viewExt; ext != "" {
+		name = strings.TrimSuffix(name, ext)
+	}
+
+	return name
+}
@@ -0,0 +1,56 @@
+package response
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+)
+
+// writer 包装 http.ResponseWriter，记录状态码及写入的字节数
+type writer struct {
+	http.ResponseWriter
+	status int
+	size   int64
+}
+
+// WriteHeader 写入状态码
+func (w *writer) WriteHeader(code int) {
+	if w.status == 0 && code >= 200 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write 写入内容
+func (w *writer) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.size += int64(n)
+	return n, err
+}
+
+// Flush 实现 http.Flusher 接口
+func (w *writer) Flush() {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack 实现 http.Hijacker 接口
+func (w *writer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if hijacker, ok := w.ResponseWriter.(http.Hijacker); ok {
+		return hijacker.Hijack()
+	}
+	return nil, nil, errors.New("response writer does not implement http.Hijacker")
+}
+
+// Unwrap 获取原始 http.ResponseWriter，供 http.ResponseController 使用
+func (w *writer) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,211 @@
+package http
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// 路由片段类型
+const (
+	segmentStatic = iota
+	segmentParam
+	segmentWildcard
+)
+
+type segment struct {
+	// 片段类型
+	kind int
+
+	// 静态片段为路径值，参数片段为参数名
+	value string
+}
+
+type route struct {
+	// 请求方法，* 表示任意方法
+	method string
+
+	// 路由规则，如 /users/:id/orders/*rest
+	pattern string
+
+	// 解析后的路由片段
+	segments []segment
+
+	// 处理器，已包含路由中间件
+	handler Handler
+
+	// 路由中间件，用于跨域预检请求
+	middlewares []Middleware
+}
+
+type router struct {
+	routes []*route
+}
+
+// add 添加路由，middlewares 仅作用于该路由
+func (rt *router) add(method string, pattern string, handler Handler, middlewares ...Middleware) error {
+	if handler == nil {
+		return errors.New("http server route(" + pattern + ") handler is nil")
+	}
+
+	method = strings.ToUpper(strings.TrimSpace(method))
+	if method == "" {
+		method = "*"
+	}
+
+	segments, err := parsePattern(pattern)
+	if err != nil {
+		return err
+	}
+
+	rt.routes = append(rt.routes, &route{
+		method:      method,
+		pattern:     pattern,
+		segments:    segments,
+		handler:     Chain(handler, middlewares...),
+		middlewares: middlewares,
+	})
+
+	// 静态片段优先于参数片段，参数片段优先于通配片段
+	sort.SliceStable(rt.routes, func(i, j int) bool {
+		return routeLess(rt.routes[i], rt.routes[j])
+	})
+
+	return nil
+}
+
+// match 匹配路由，路径匹配但方法不匹配时返回允许的方法列表
+func (rt *router) match(method string, path string) (*route, map[string]string, []string) {
+	parts := splitPath(path)
+
+	var allowed []string
+	for _, r := range rt.routes {
+		params, ok := r.match(parts)
+		if !ok {
+			continue
+		}
+
+		if r.method == "*" || r.method == method || (method == "HEAD" && r.method == "GET") {
+			return r, params, nil
+		}
+
+		exists := false
+		for _, m := range allowed {
+			if m == r.method {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			allowed = append(allowed, r.method)
+		}
+	}
+
+	return nil, nil, allowed
+}
+
+// match 按片段匹配路径
+func (r *route) match(parts []string) (map[string]string, bool) {
+	var params map[string]string
+
+	for i, seg := range r.segments {
+		if seg.kind == segmentWildcard {
+			if params == nil {
+				params = make(map[string]string)
+			}
+			params[seg.value] = strings.Join(parts[i:], "/")
+			return params, true
+		}
+
+		if i >= len(parts) {
+			return nil, false
+		}
+
+		switch seg.kind {
+		case segmentStatic:
+			if parts[i] != seg.value {
+				return nil, false
+			}
+		case segmentParam:
+			if parts[i] == "" {
+				return nil, false
+			}
+			if params == nil {
+				params = make(map[string]string)
+			}
+			params[seg.value] = parts[i]
+		}
+	}
+
+	if len(parts) != len(r.segments) {
+		return nil, false
+	}
+
+	return params, true
+}
+
+// parsePattern 解析路由规则
+func parsePattern(pattern string) ([]segment, error) {
+	if !strings.HasPrefix(pattern, "/") {
+		return nil, errors.New("http server route(" + pattern + ") must start with '/'")
+	}
+
+	parts := splitPath(pattern)
+	segments := make([]segment, 0, len(parts))
+	names := make(map[string]bool)
+
+	for i, part := range parts {
+		switch {
+		case strings.HasPrefix(part, ":"):
+			name := part[1:]
+			if name == "" || names[name] {
+				return nil, errors.New("http server route(" + pattern + ") has an invalid parameter name")
+			}
+			names[name] = true
+			segments = append(segments, segment{kind: segmentParam, value: name})
+		case strings.HasPrefix(part, "*"):
+			if i != len(parts)-1 {
+				return nil, errors.New("http server route(" + pattern + ") wildcard must be the last segment")
+			}
+			name := part[1:]
+			if name == "" {
+				name = "*"
+			}
+			if names[name] {
+				return nil, errors.New("http server route(" + pattern + ") has an invalid parameter name")
+			}
+			names[name] = true
+			segments = append(segments, segment{kind: segmentWildcard, value: name})
+		default:
+			segments = append(segments, segment{kind: segmentStatic, value: part})
+		}
+	}
+
+	return segments, nil
+}
+
+// splitPath 将路径拆分为片段，忽略首尾的 /
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return []string{}
+	}
+
+	return strings.Split(path, "/")
+}
+
+// routeLess 路由优先级比较
+func routeLess(a *route, b *route) bool {
+	l := len(a.segments)
+	if len(b.segments) < l {
+		l = len(b.segments)
+	}
+
+	for i := 0; i < l; i++ {
+		if a.segments[i].kind != b.segments[i].kind {
+			return a.segments[i].kind < b.segments[i].kind
+		}
+	}
+
+	return len(a.segments) < len(b.segments)
+}
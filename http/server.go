@@ -1,12 +1,28 @@
 package http
 
 import (
+	"context"
+	"crypto/tls"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"runtime/debug"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
 
 	"github.com/go-ini/ini"
+	"github.com/go-nt/nt/util/crypto/rand"
 )
 
 type Config struct {
@@ -15,6 +31,76 @@ type Config struct {
 
 	// 默认处理器名称
 	defaultHandlerName string
+
+	// 收到退出信号后等待处理中请求完成的最长时间
+	shutdownTimeout time.Duration
+
+	// 证书文件路径，设置后启用 https
+	certFile string
+
+	// 私钥文件路径
+	keyFile string
+
+	// 最低 TLS 版本
+	tlsMinVersion uint16
+
+	// 客户端 CA 证书文件路径，设置后启用双向认证
+	clientCaFile string
+
+	// 客户端证书校验方式：none | request | require | verify_if_given | require_and_verify
+	clientAuth string
+
+	// http 跳转到 https 的监听端口，0-不启用
+	redirectPort int
+
+	// 证书文件变更后是否自动重新加载
+	certReload bool
+
+	// 监听的主机名或 IP，为空时监听所有地址
+	host string
+
+	// unix socket 文件路径，设置后不再监听 host:port
+	unixSocket string
+
+	// 读取整个请求（含请求体）的超时时间，0-不限制
+	// 设置后会中断超时未传完的大文件上传及流式读取的请求体，默认不限制，由 readHeaderTimeout 防止慢速请求头
+	readTimeout time.Duration
+
+	// 读取请求头的超时时间，0-不限制
+	readHeaderTimeout time.Duration
+
+	// 写入响应的超时时间，0-不限制
+	writeTimeout time.Duration
+
+	// keep-alive 连接空闲超时时间，0-不限制
+	idleTimeout time.Duration
+
+	// 请求头最大字节数
+	maxHeaderBytes int
+
+	// 请求体最大字节数，0-不限制
+	maxBodyBytes int64
+
+	// 是否记录访问日志
+	accessLog bool
+
+	// 访问日志格式：text | json | combined
+	accessLogFormat string
+
+	// 访问日志文件路径，为空时输出到标准输出
+	accessLogFile string
+
+	// 访问日志文件切割大小（MB），0-不切割
+	accessLogMaxSize int64
+
+	// 访问日志保留的历史文件个数，0-不限制
+	accessLogMaxBackups int
+
+	// 跨域配置，设置允许的来源后启用
+	cors CorsConfig
+
+	// 请求 ID 请求头/响应头名称
+	requestIdHeader string
 }
 
 type Server struct {
@@ -23,6 +109,32 @@ type Server struct {
 
 	// 处理器
 	handlers map[string]Handler
+
+	// 路由
+	router *router
+
+	// 静态文件目录
+	statics []*static
+
+	// 全局中间件
+	middlewares []Middleware
+
+	// 错误处理器
+	errorHandler ErrorHandler
+
+	// 访问日志，关闭时可能仍有请求在记录，以原子方式读写
+	accessLogger    atomic.Pointer[slog.Logger]
+	accessLogCloser io.Closer
+
+	// 启动钩子
+	startHooks []func() error
+
+	// 关闭钩子
+	shutdownHooks []func(ctx context.Context) error
+
+	mu          sync.Mutex
+	httpServers []*http.Server
+	stopped     chan struct{}
 }
 
 // initConfig 初始化配置
@@ -32,6 +144,27 @@ func (server *Server) initConfig() {
 		port: 9999,
 
 		defaultHandlerName: "",
+
+		shutdownTimeout: 30 * time.Second,
+
+		tlsMinVersion: tls.VersionTLS12,
+
+		host:              "",
+		unixSocket:        "",
+		readTimeout:       0,
+		readHeaderTimeout: 10 * time.Second,
+		writeTimeout:      0,
+		idleTimeout:       120 * time.Second,
+		maxHeaderBytes:    1 << 20,
+		maxBodyBytes:      0,
+
+		accessLog:           false,
+		accessLogFormat:     "text",
+		accessLogFile:       "",
+		accessLogMaxSize:    100,
+		accessLogMaxBackups: 7,
+
+		requestIdHeader: "X-Request-ID",
 	}
 }
 
@@ -61,10 +194,213 @@ func (server *Server) SetConfig(config map[string]any) error {
 					return errors.New("http server config parameter(default_handler_name) is not a valid value")
 				}
 			}
+		case "shutdownTimeout", "shutdown_timeout":
+			t, ok := parseDuration(value)
+			if ok && t >= 0 {
+				server.config.shutdownTimeout = t
+			} else {
+				return errors.New("http server config parameter(shutdown_timeout) is not a valid value")
+			}
+		case "certFile", "cert_file":
+			switch t := value.(type) {
+			case string:
+				server.config.certFile = t
+			}
+		case "keyFile", "key_file":
+			switch t := value.(type) {
+			case string:
+				server.config.keyFile = t
+			}
+		case "tlsMinVersion", "tls_min_version":
+			switch t := value.(type) {
+			case string:
+				if version, ok := parseTlsVersion(t); ok {
+					server.config.tlsMinVersion = version
+				} else {
+					return errors.New("http server config parameter(tls_min_version) is not a valid value")
+				}
+			case uint16:
+				server.config.tlsMinVersion = t
+			}
+		case "clientCaFile", "client_ca_file":
+			switch t := value.(type) {
+			case string:
+				server.config.clientCaFile = t
+			}
+		case "clientAuth", "client_auth":
+			switch t := value.(type) {
+			case string:
+				if _, ok := parseClientAuth(t); ok {
+					server.config.clientAuth = t
+				} else {
+					return errors.New("http server config parameter(client_auth) is not a valid value")
+				}
+			}
+		case "redirectPort", "redirect_port":
+			switch t := value.(type) {
+			case int:
+				if t >= 0 && t < 65535 {
+					server.config.redirectPort = t
+				} else {
+					return errors.New("http server config parameter(redirect_port) is not a valid value")
+				}
+			}
+		case "certReload", "cert_reload":
+			switch t := value.(type) {
+			case bool:
+				server.config.certReload = t
+			}
+		case "host":
+			switch t := value.(type) {
+			case string:
+				server.config.host = t
+			}
+		case "unixSocket", "unix_socket":
+			switch t := value.(type) {
+			case string:
+				server.config.unixSocket = t
+			}
+		case "readTimeout", "read_timeout":
+			t, ok := parseDuration(value)
+			if ok && t >= 0 {
+				server.config.readTimeout = t
+			} else {
+				return errors.New("http server config parameter(read_timeout) is not a valid value")
+			}
+		case "readHeaderTimeout", "read_header_timeout":
+			t, ok := parseDuration(value)
+			if ok && t >= 0 {
+				server.config.readHeaderTimeout = t
+			} else {
+				return errors.New("http server config parameter(read_header_timeout) is not a valid value")
+			}
+		case "writeTimeout", "write_timeout":
+			t, ok := parseDuration(value)
+			if ok && t >= 0 {
+				server.config.writeTimeout = t
+			} else {
+				return errors.New("http server config parameter(write_timeout) is not a valid value")
+			}
+		case "idleTimeout", "idle_timeout":
+			t, ok := parseDuration(value)
+			if ok && t >= 0 {
+				server.config.idleTimeout = t
+			} else {
+				return errors.New("http server config parameter(idle_timeout) is not a valid value")
+			}
+		case "maxHeaderBytes", "max_header_bytes":
+			switch t := value.(type) {
+			case int:
+				if t > 0 {
+					server.config.maxHeaderBytes = t
+				} else {
+					return errors.New("http server config parameter(max_header_bytes) is not a valid value")
+				}
+			}
+		case "maxBodyBytes", "max_body_bytes":
+			switch t := value.(type) {
+			case int:
+				if t >= 0 {
+					server.config.maxBodyBytes = int64(t)
+				} else {
+					return errors.New("http server config parameter(max_body_bytes) is not a valid value")
+				}
+			case int64:
+				if t >= 0 {
+					server.config.maxBodyBytes = t
+				} else {
+					return errors.New("http server config parameter(max_body_bytes) is not a valid value")
+				}
+			}
+		case "accessLog", "access_log":
+			switch t := value.(type) {
+			case bool:
+				server.config.accessLog = t
+			}
+		case "accessLogFormat", "access_log_format":
+			switch t := value.(type) {
+			case string:
+				if t == "text" || t == "json" || t == "combined" {
+					server.config.accessLogFormat = t
+				} else {
+					return errors.New("http server config parameter(access_log_format) is not a valid value")
+				}
+			}
+		case "accessLogFile", "access_log_file":
+			switch t := value.(type) {
+			case string:
+				server.config.accessLogFile = t
+			}
+		case "accessLogMaxSize", "access_log_max_size":
+			switch t := value.(type) {
+			case int:
+				if t >= 0 {
+					server.config.accessLogMaxSize = int64(t)
+				} else {
+					return errors.New("http server config parameter(access_log_max_size) is not a valid value")
+				}
+			}
+		case "accessLogMaxBackups", "access_log_max_backups":
+			switch t := value.(type) {
+			case int:
+				if t >= 0 {
+					server.config.accessLogMaxBackups = t
+				} else {
+					return errors.New("http server config parameter(access_log_max_backups) is not a valid value")
+				}
+			}
+		case "corsAllowOrigins", "cors_allow_origins":
+			if t, ok := parseList(value); ok {
+				server.config.cors.AllowOrigins = t
+			} else {
+				return errors.New("http server config parameter(cors_allow_origins) is not a valid value")
+			}
+		case "corsAllowMethods", "cors_allow_methods":
+			if t, ok := parseList(value); ok {
+				for i := range t {
+					t[i] = strings.ToUpper(t[i])
+				}
+				server.config.cors.AllowMethods = t
+			} else {
+				return errors.New("http server config parameter(cors_allow_methods) is not a valid value")
+			}
+		case "corsAllowHeaders", "cors_allow_headers":
+			if t, ok := parseList(value); ok {
+				server.config.cors.AllowHeaders = t
+			} else {
+				return errors.New("http server config parameter(cors_allow_headers) is not a valid value")
+			}
+		case "corsExposeHeaders", "cors_expose_headers":
+			if t, ok := parseList(value); ok {
+				server.config.cors.ExposeHeaders = t
+			} else {
+				return errors.New("http server config parameter(cors_expose_headers) is not a valid value")
+			}
+		case "corsAllowCredentials", "cors_allow_credentials":
+			switch t := value.(type) {
+			case bool:
+				server.config.cors.AllowCredentials = t
+			}
+		case "requestIdHeader", "request_id_header":
+			switch t := value.(type) {
+			case string:
+				if t != "" {
+					server.config.requestIdHeader = t
+				} else {
+					return errors.New("http server config parameter(request_id_header) is not a valid value")
+				}
+			}
+		case "corsMaxAge", "cors_max_age":
+			t, ok := parseDuration(value)
+			if ok && t >= 0 {
+				server.config.cors.MaxAge = t
+			} else {
+				return errors.New("http server config parameter(cors_max_age) is not a valid value")
+			}
 		}
 	}
 
-	return nil
+	return server.config.cors.validate()
 }
 
 // SetIniConfig ini 参数配置
@@ -103,75 +439,326 @@ func (server *Server) SetIniConfig(section *ini.Section) error {
 		}
 	}
 
-	return nil
+	configKeyShutdownTimeout, err := getIniKey(section, "shutdownTimeout", "shutdown_timeout")
+	if err == nil {
+		t, ok := parseDuration(configKeyShutdownTimeout.String())
+		if ok && t >= 0 {
+			server.config.shutdownTimeout = t
+		} else {
+			return errors.New("http server config parameter(shutdown_timeout) is not a valid value")
+		}
+	}
+
+	configKeyCertFile, err := getIniKey(section, "certFile", "cert_file")
+	if err == nil {
+		server.config.certFile = configKeyCertFile.String()
+	}
+
+	configKeyKeyFile, err := getIniKey(section, "keyFile", "key_file")
+	if err == nil {
+		server.config.keyFile = configKeyKeyFile.String()
+	}
+
+	configKeyTlsMinVersion, err := getIniKey(section, "tlsMinVersion", "tls_min_version")
+	if err == nil {
+		if version, ok := parseTlsVersion(configKeyTlsMinVersion.String()); ok {
+			server.config.tlsMinVersion = version
+		} else {
+			return errors.New("http server config parameter(tls_min_version) is not a valid value")
+		}
+	}
+
+	configKeyClientCaFile, err := getIniKey(section, "clientCaFile", "client_ca_file")
+	if err == nil {
+		server.config.clientCaFile = configKeyClientCaFile.String()
+	}
+
+	configKeyClientAuth, err := getIniKey(section, "clientAuth", "client_auth")
+	if err == nil {
+		t := configKeyClientAuth.String()
+		if _, ok := parseClientAuth(t); ok {
+			server.config.clientAuth = t
+		} else {
+			return errors.New("http server config parameter(client_auth) is not a valid value")
+		}
+	}
+
+	configKeyRedirectPort, err := getIniKey(section, "redirectPort", "redirect_port")
+	if err == nil {
+		t, err := configKeyRedirectPort.Int()
+		if err == nil && t >= 0 && t < 65535 {
+			server.config.redirectPort = t
+		} else {
+			return errors.New("http server config parameter(redirect_port) is not a valid value")
+		}
+	}
+
+	configKeyCertReload, err := getIniKey(section, "certReload", "cert_reload")
+	if err == nil {
+		t, err := configKeyCertReload.Bool()
+		if err == nil {
+			server.config.certReload = t
+		} else {
+			return errors.New("http server config parameter(cert_reload) is not a valid value")
+		}
+	}
+
+	configKeyHost, err := getIniKey(section, "host")
+	if err == nil {
+		server.config.host = configKeyHost.String()
+	}
+
+	configKeyUnixSocket, err := getIniKey(section, "unixSocket", "unix_socket")
+	if err == nil {
+		server.config.unixSocket = configKeyUnixSocket.String()
+	}
+
+	durations := []struct {
+		names []string
+		value *time.Duration
+	}{
+		{[]string{"readTimeout", "read_timeout"}, &server.config.readTimeout},
+		{[]string{"readHeaderTimeout", "read_header_timeout"}, &server.config.readHeaderTimeout},
+		{[]string{"writeTimeout", "write_timeout"}, &server.config.writeTimeout},
+		{[]string{"idleTimeout", "idle_timeout"}, &server.config.idleTimeout},
+	}
+	for _, d := range durations {
+		configKey, err := getIniKey(section, d.names...)
+		if err == nil {
+			t, ok := parseDuration(configKey.String())
+			if ok && t >= 0 {
+				*d.value = t
+			} else {
+				return errors.New("http server config parameter(" + d.names[1] + ") is not a valid value")
+			}
+		}
+	}
+
+	configKeyMaxHeaderBytes, err := getIniKey(section, "maxHeaderBytes", "max_header_bytes")
+	if err == nil {
+		t, err := configKeyMaxHeaderBytes.Int()
+		if err == nil && t > 0 {
+			server.config.maxHeaderBytes = t
+		} else {
+			return errors.New("http server config parameter(max_header_bytes) is not a valid value")
+		}
+	}
+
+	configKeyMaxBodyBytes, err := getIniKey(section, "maxBodyBytes", "max_body_bytes")
+	if err == nil {
+		t, err := configKeyMaxBodyBytes.Int64()
+		if err == nil && t >= 0 {
+			server.config.maxBodyBytes = t
+		} else {
+			return errors.New("http server config parameter(max_body_bytes) is not a valid value")
+		}
+	}
+
+	configKeyAccessLog, err := getIniKey(section, "accessLog", "access_log")
+	if err == nil {
+		t, err := configKeyAccessLog.Bool()
+		if err == nil {
+			server.config.accessLog = t
+		} else {
+			return errors.New("http server config parameter(access_log) is not a valid value")
+		}
+	}
+
+	configKeyAccessLogFormat, err := getIniKey(section, "accessLogFormat", "access_log_format")
+	if err == nil {
+		t := configKeyAccessLogFormat.String()
+		if t == "text" || t == "json" || t == "combined" {
+			server.config.accessLogFormat = t
+		} else {
+			return errors.New("http server config parameter(access_log_format) is not a valid value")
+		}
+	}
+
+	configKeyAccessLogFile, err := getIniKey(section, "accessLogFile", "access_log_file")
+	if err == nil {
+		server.config.accessLogFile = configKeyAccessLogFile.String()
+	}
+
+	configKeyAccessLogMaxSize, err := getIniKey(section, "accessLogMaxSize", "access_log_max_size")
+	if err == nil {
+		t, err := configKeyAccessLogMaxSize.Int64()
+		if err == nil && t >= 0 {
+			server.config.accessLogMaxSize = t
+		} else {
+			return errors.New("http server config parameter(access_log_max_size) is not a valid value")
+		}
+	}
+
+	configKeyAccessLogMaxBackups, err := getIniKey(section, "accessLogMaxBackups", "access_log_max_backups")
+	if err == nil {
+		t, err := configKeyAccessLogMaxBackups.Int()
+		if err == nil && t >= 0 {
+			server.config.accessLogMaxBackups = t
+		} else {
+			return errors.New("http server config parameter(access_log_max_backups) is not a valid value")
+		}
+	}
+
+	lists := []struct {
+		names []string
+		value *[]string
+	}{
+		{[]string{"corsAllowOrigins", "cors_allow_origins"}, &server.config.cors.AllowOrigins},
+		{[]string{"corsAllowMethods", "cors_allow_methods"}, &server.config.cors.AllowMethods},
+		{[]string{"corsAllowHeaders", "cors_allow_headers"}, &server.config.cors.AllowHeaders},
+		{[]string{"corsExposeHeaders", "cors_expose_headers"}, &server.config.cors.ExposeHeaders},
+	}
+	for _, l := range lists {
+		configKey, err := getIniKey(section, l.names...)
+		if err == nil {
+			*l.value, _ = parseList(configKey.String())
+		}
+	}
+	for i := range server.config.cors.AllowMethods {
+		server.config.cors.AllowMethods[i] = strings.ToUpper(server.config.cors.AllowMethods[i])
+	}
+
+	configKeyRequestIdHeader, err := getIniKey(section, "requestIdHeader", "request_id_header")
+	if err == nil {
+		t := configKeyRequestIdHeader.String()
+		if t != "" {
+			server.config.requestIdHeader = t
+		} else {
+			return errors.New("http server config parameter(request_id_header) is not a valid value")
+		}
+	}
+
+	configKeyCorsAllowCredentials, err := getIniKey(section, "corsAllowCredentials", "cors_allow_credentials")
+	if err == nil {
+		t, err := configKeyCorsAllowCredentials.Bool()
+		if err == nil {
+			server.config.cors.AllowCredentials = t
+		} else {
+			return errors.New("http server config parameter(cors_allow_credentials) is not a valid value")
+		}
+	}
+
+	configKeyCorsMaxAge, err := getIniKey(section, "corsMaxAge", "cors_max_age")
+	if err == nil {
+		t, ok := parseDuration(configKeyCorsMaxAge.String())
+		if ok && t >= 0 {
+			server.config.cors.MaxAge = t
+		} else {
+			return errors.New("http server config parameter(cors_max_age) is not a valid value")
+		}
+	}
+
+	return server.config.cors.validate()
+}
+
+// Use 添加全局中间件，作用于所有请求
+func (server *Server) Use(middlewares ...Middleware) {
+	server.middlewares = append(server.middlewares, middlewares...)
 }
 
-func (server *Server) AddHandler(handlerName string, handler Handler) {
+// AddHandler 添加处理器，middlewares 仅作用于该处理器
+func (server *Server) AddHandler(handlerName string, handler Handler, middlewares ...Middleware) {
 	if server.handlers == nil {
 		server.handlers = make(map[string]Handler)
 	}
 
-	server.handlers[handlerName] = handler
+	server.handlers[handlerName] = Chain(handler, middlewares...)
+}
+
+// AddRoute 添加路由，method 为空或 * 时匹配任意请求方法
+// pattern 支持参数片段 :name 及末尾的通配片段 *name，如 /users/:id/orders/*rest
+// middlewares 仅作用于该路由，跨域预检请求经过所请求方法对应路由的中间件，可由其中的 Cors 中间件响应
+func (server *Server) AddRoute(method string, pattern string, handler Handler, middlewares ...Middleware) error {
+	if server.router == nil {
+		server.router = new(router)
+	}
+
+	return server.router.add(method, pattern, handler, middlewares...)
+}
+
+// SetErrorHandler 设置错误处理器，处理器返回的错误、panic 及 404/405 均由其输出
+func (server *Server) SetErrorHandler(errorHandler ErrorHandler) {
+	server.errorHandler = errorHandler
 }
 
-// Start 启动服务
-func (server *Server) Start() {
+// OnStart 添加启动钩子，在开始监听前按添加顺序执行，返回错误时终止启动
+func (server *Server) OnStart(hook func() error) {
+	server.startHooks = append(server.startHooks, hook)
+}
+
+// OnShutdown 添加关闭钩子，在处理中的请求完成后按添加顺序执行，可用于关闭数据库、redis 连接池
+func (server *Server) OnShutdown(hook func(ctx context.Context) error) {
+	server.shutdownHooks = append(server.shutdownHooks, hook)
+}
+
+// Start 启动服务，阻塞至服务关闭
+// 收到 SIGINT/SIGTERM 信号时自动优雅关闭，通过 Shutdown 关闭时返回 nil
+func (server *Server) Start() error {
 
 	if server.config == nil {
 		server.initConfig()
 	}
 
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-
-		if server.handlers != nil {
+	for _, hook := range server.startHooks {
+		if err := hook(); err != nil {
+			return err
+		}
+	}
 
-			path := r.URL.Path
-			if path == "/favicon.ico" {
-				return
-			}
+	var tlsConfig *tls.Config
+	if server.config.certFile != "" {
+		var err error
+		tlsConfig, err = server.newTlsConfig()
+		if err != nil {
+			return err
+		}
+	}
 
-			handlerName := ""
-			i := 1
-			l := len(path)
-			for i < l {
-				if path[i] == '/' {
-					handlerName = path[1:i]
-					break
-				}
-				i++
-			}
+	listener, err := server.listen()
+	if err != nil {
+		return err
+	}
 
-			if handlerName == "" && l > 1 {
-				handlerName = path[1:l]
-			}
+	httpServer := server.newHttpServer(server)
+	httpServer.TLSConfig = tlsConfig
 
-			if handlerName == "" && server.config.defaultHandlerName != "" {
-				handlerName = server.config.defaultHandlerName
-			}
+	httpServers := []*http.Server{httpServer}
+	listeners := []net.Listener{listener}
 
-			if handlerName == "" {
-				_, err := w.Write([]byte("<a href=\"https://www.go-nt.com\" target=\"_blank\">GO-NT</a> framework!"))
-				if err != nil {
-					return
-				}
-			} else {
-				if handler, ok := server.handlers[handlerName]; ok {
+	if tlsConfig != nil && server.config.redirectPort > 0 {
+		redirectListener, err := net.Listen("tcp", server.address(server.config.redirectPort))
+		if err != nil {
+			_ = listener.Close()
+			return err
+		}
 
-					c := new(Context)
-					c.Init(r, w)
+		httpServers = append(httpServers, server.newHttpServer(http.HandlerFunc(server.redirectToHttps)))
+		listeners = append(listeners, redirectListener)
+	}
 
-					handler.OnRequest(c)
-				} else {
-					_, err := w.Write([]byte("Handler(" + handlerName + ") does not exist"))
-					if err != nil {
-						return
-					}
-				}
-			}
+	// 监听成功后再打开访问日志文件，避免启动失败时文件未关闭
+	if err := server.initAccessLogger(); err != nil {
+		for _, ln := range listeners {
+			_ = ln.Close()
 		}
-	})
+		return err
+	}
 
-	fmt.Println("go-nt http server is listening on prot " + strconv.Itoa(int(server.config.port)))
+	stopped := make(chan struct{})
+
+	server.mu.Lock()
+	server.httpServers = httpServers
+	server.stopped = stopped
+	server.mu.Unlock()
+
+	if tlsConfig != nil {
+		fmt.Println("go-nt http server is listening on " + listener.Addr().String() + " (https)")
+		if server.config.redirectPort > 0 {
+			fmt.Println("go-nt http server is redirecting http to https on " + listeners[1].Addr().String())
+		}
+	} else {
+		fmt.Println("go-nt http server is listening on " + listener.Addr().String())
+	}
 	fmt.Println("go-nt http server handlers:")
 	for handlerName := range server.handlers {
 		if handlerName == server.config.defaultHandlerName {
@@ -180,11 +767,320 @@ func (server *Server) Start() {
 			fmt.Println(handlerName)
 		}
 	}
+	if server.router != nil {
+		fmt.Println("go-nt http server routes:")
+		for _, r := range server.router.routes {
+			fmt.Println(r.method + " " + r.pattern)
+		}
+	}
 
-	err := http.ListenAndServe(":"+strconv.Itoa(int(server.config.port)), nil)
-	if err != nil {
-		fmt.Println("go-nt http server started err: ", err)
+	errCh := make(chan error, len(httpServers))
+	for i, hs := range httpServers {
+		go func(hs *http.Server, ln net.Listener) {
+			if hs.TLSConfig != nil {
+				errCh <- hs.ServeTLS(ln, "", "")
+			} else {
+				errCh <- hs.Serve(ln)
+			}
+		}(hs, listeners[i])
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			<-stopped
+			return nil
+		}
+
+		// 任一监听失败时关闭其余监听
+		server.mu.Lock()
+		owned := server.stopped == stopped && server.httpServers != nil
+		if owned {
+			server.httpServers = nil
+		}
+		server.mu.Unlock()
+
+		if owned {
+			for _, hs := range httpServers {
+				_ = hs.Close()
+			}
+			_ = server.closeAccessLogger()
+			close(stopped)
+		} else {
+			<-stopped
+		}
+
+		return err
+	case sig := <-sigCh:
+		fmt.Println("go-nt http server received signal " + sig.String() + ", shutting down")
+
+		ctx, cancel := context.WithTimeout(context.Background(), server.config.shutdownTimeout)
+		defer cancel()
+
+		return server.Shutdown(ctx)
+	}
+}
+
+// Shutdown 优雅关闭服务，停止接收新连接并等待处理中的请求完成，随后执行关闭钩子
+func (server *Server) Shutdown(ctx context.Context) error {
+	server.mu.Lock()
+	httpServers := server.httpServers
+	stopped := server.stopped
+	server.httpServers = nil
+	server.mu.Unlock()
+
+	if httpServers == nil {
+		return errors.New("go-nt http server is not running")
+	}
+
+	var errs []error
+	for _, hs := range httpServers {
+		if err := hs.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, hook := range server.shutdownHooks {
+		if err := hook(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := server.closeAccessLogger(); err != nil {
+		errs = append(errs, err)
+	}
+
+	close(stopped)
+
+	return errors.Join(errs...)
+}
+
+// newHttpServer 按配置的超时及限制创建 http.Server
+func (server *Server) newHttpServer(handler http.Handler) *http.Server {
+	return &http.Server{
+		Handler:           handler,
+		ReadTimeout:       server.config.readTimeout,
+		ReadHeaderTimeout: server.config.readHeaderTimeout,
+		WriteTimeout:      server.config.writeTimeout,
+		IdleTimeout:       server.config.idleTimeout,
+		MaxHeaderBytes:    server.config.maxHeaderBytes,
+	}
+}
+
+// listen 创建监听，配置了 unixSocket 时监听 unix socket，否则监听 host:port
+func (server *Server) listen() (net.Listener, error) {
+	if server.config.unixSocket != "" {
+		// 清理上次未正常退出时遗留的 socket 文件
+		if info, err := os.Stat(server.config.unixSocket); err == nil && info.Mode()&os.ModeSocket != 0 {
+			_ = os.Remove(server.config.unixSocket)
+		}
+
+		return net.Listen("unix", server.config.unixSocket)
+	}
+
+	return net.Listen("tcp", server.address(server.config.port))
+}
+
+// address 监听地址
+func (server *Server) address(port int) string {
+	return net.JoinHostPort(server.config.host, strconv.Itoa(port))
+}
+
+// Handler 获取服务对应的 http.Handler，可挂载到其它 ServeMux 或用于 httptest.NewServer
+func (server *Server) Handler() http.Handler {
+	return server
+}
+
+// ServeHTTP 实现 http.Handler 接口，经全局中间件后交由匹配的处理器处理
+func (server *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+
+	if server.config != nil && server.config.maxBodyBytes > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, server.config.maxBodyBytes)
+	}
+
+	c := new(Context)
+	c.Init(r, w)
+	c.errorHandler = server.errorHandler
+
+	requestIdHeader := "X-Request-ID"
+	if server.config != nil {
+		requestIdHeader = server.config.requestIdHeader
+	}
+	c.setRequestId(requestIdHeader, requestId(r.Header.Get(requestIdHeader)))
+
+	if logger := server.accessLogger.Load(); logger != nil {
+		defer server.logAccess(logger, c, start)
+	}
+
+	// 替换过 context.Context 的请求不会由 net/http 清理 multipart 临时文件
+	defer func() {
+		if req := c.Request.Request; req != r && req.MultipartForm != nil {
+			_ = req.MultipartForm.RemoveAll()
+		}
+	}()
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			// 客户端断开等场景下由 net/http 主动中止，不作处理
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+
+			log.Printf("go-nt http server panic: %v\n%s", rec, debug.Stack())
+
+			// 响应已开始输出时无法再输出错误页，仅记录日志
+			if c.Response.Written() {
+				return
+			}
+			c.Error(NewStatusError(http.StatusInternalServerError, "", fmt.Errorf("panic: %v", rec)))
+		}
+	}()
+
+	// 跨域预检请求在分发前直接响应
+	if server.config != nil && server.config.cors.enabled() && server.config.cors.handle(c) {
 		return
 	}
 
+	Chain(server.resolve(c), server.middlewares...).OnRequest(c)
+}
+
+// resolve 解析请求对应的处理器，优先匹配路由，其次按路径首段匹配处理器名称
+func (server *Server) resolve(c *Context) Handler {
+	r := c.Request.Request
+	path := r.URL.Path
+
+	var allowed []string
+	if server.router != nil {
+		var matched *route
+		var params map[string]string
+		matched, params, allowed = server.router.match(r.Method, path)
+		if matched != nil {
+			c.Request.SetParams(params)
+			c.handlerName = matched.method + " " + matched.pattern
+			return matched.handler
+		}
+
+		// 跨域预检请求交由所请求方法对应路由的中间件处理，未被 Cors 中间件响应时返回 405
+		if len(allowed) > 0 && isPreflight(c) {
+			matched, params, _ = server.router.match(c.Request.Header("Access-Control-Request-Method", ""), path)
+			if matched != nil {
+				c.Request.SetParams(params)
+				c.handlerName = matched.method + " " + matched.pattern
+				return Chain(methodNotAllowed(allowed), matched.middlewares...)
+			}
+		}
+	}
+
+	handlerName := ""
+	i := 1
+	l := len(path)
+	for i < l {
+		if path[i] == '/' {
+			handlerName = path[1:i]
+			break
+		}
+		i++
+	}
+
+	if handlerName == "" && l > 1 {
+		handlerName = path[1:l]
+	}
+
+	if handlerName == "" && server.config != nil && server.config.defaultHandlerName != "" {
+		handlerName = server.config.defaultHandlerName
+	}
+
+	if handler, ok := server.handlers[handlerName]; ok {
+		c.handlerName = handlerName
+		return handler
+	}
+
+	for _, s := range server.statics {
+		if _, ok := s.match(path); ok {
+			if r.Method == "GET" || r.Method == "HEAD" {
+				c.handlerName = "static " + s.prefix
+				return s
+			}
+
+			allowed = append(allowed, "GET", "HEAD")
+			break
+		}
+	}
+
+	if len(allowed) > 0 {
+		return methodNotAllowed(allowed)
+	}
+
+	if handlerName == "" {
+		return HandlerFunc(func(c *Context) {
+			c.Response.Write("<a href=\"https://www.go-nt.com\" target=\"_blank\">GO-NT</a> framework!")
+		})
+	}
+
+	return HandlerFunc(func(c *Context) {
+		c.Error(NewStatusError(http.StatusNotFound, ""))
+	})
+}
+
+// methodNotAllowed 返回 405 的处理器，allowed 为允许的请求方法
+func methodNotAllowed(allowed []string) Handler {
+	return HandlerFunc(func(c *Context) {
+		c.Response.Header("Allow", strings.Join(allowed, ", "))
+		c.Error(NewStatusError(http.StatusMethodNotAllowed, ""))
+	})
+}
+
+// getIniKey 按顺序获取 ini 配置项，兼容驼峰和下划线两种命名
+func getIniKey(section *ini.Section, names ...string) (*ini.Key, error) {
+	for _, name := range names {
+		key, err := section.GetKey(name)
+		if err == nil {
+			return key, nil
+		}
+	}
+
+	return nil, errors.New("http server config key(" + strings.Join(names, "|") + ") not found")
+}
+
+// parseDuration 解析时长，支持 time.Duration、秒数及 "10s" 格式的字符串
+func parseDuration(value any) (time.Duration, bool) {
+	switch t := value.(type) {
+	case time.Duration:
+		return t, true
+	case int:
+		return time.Duration(t) * time.Second, true
+	case string:
+		if seconds, err := strconv.Atoi(t); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+		du, err := time.ParseDuration(t)
+		if err == nil {
+			return du, true
+		}
+	}
+
+	return 0, false
+}
+
+// requestId 沿用客户端传入的合法请求 ID，否则生成新的请求 ID
+func requestId(id string) string {
+	if id != "" && len(id) <= 128 {
+		valid := true
+		for i := 0; i < len(id); i++ {
+			if id[i] < 0x21 || id[i] > 0x7e {
+				valid = false
+				break
+			}
+		}
+		if valid {
+			return id
+		}
+	}
+
+	return hex.EncodeToString(rand.Bytes(16))
 }
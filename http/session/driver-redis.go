@@ -37,7 +37,7 @@ func (d *DriverRedis) Init(config *Config, ctx *ntHttp.Context) {
 	if d.id != "" {
 		err := uuid.Validate(d.id)
 		if err == nil {
-			dataJson, err := d.redis.GetClient().Get(context.TODO(), "session:"+d.id).Bytes()
+			dataJson, err := d.redis.GetClient().Get(d.ctx.Context(), "session:"+d.id).Bytes()
 			if err == nil {
 				var data map[string]any
 				err = json.Unmarshal(dataJson, &data)
@@ -72,9 +72,9 @@ func (d *DriverRedis) Save() {
 		redisKey := "session:" + d.id
 		if len(d.data) > 0 {
 			data, _ := json.Marshal(d.data)
-			d.redis.GetClient().Set(context.TODO(), redisKey, data, time.Duration(d.config.expire))
+			d.redis.GetClient().Set(context.WithoutCancel(d.ctx.Context()), redisKey, data, time.Duration(d.config.expire))
 		} else {
-			d.redis.GetClient().Del(context.TODO(), redisKey)
+			d.redis.GetClient().Del(context.WithoutCancel(d.ctx.Context()), redisKey)
 		}
 	}
 }
@@ -0,0 +1,235 @@
+package http
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StaticConfig 静态文件目录配置
+type StaticConfig struct {
+	// 目录默认文件，为空时使用 index.html
+	Index string
+
+	// 单页应用模式，文件不存在且路径无扩展名时返回根目录的默认文件
+	Spa bool
+
+	// 缓存时间，设置 Cache-Control: max-age，默认文件始终为 no-cache
+	MaxAge time.Duration
+
+	// 是否优先使用预压缩的 .br / .gz 文件
+	Precompressed bool
+}
+
+type static struct {
+	// 挂载路径，如 /assets
+	prefix string
+
+	// 文件系统
+	fsys fs.FS
+
+	// 配置
+	config StaticConfig
+}
+
+// AddStatic 挂载静态文件目录，如 AddStatic("/assets", "./public")
+func (server *Server) AddStatic(prefix string, dir string, config ...StaticConfig) error {
+	fInfo, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+
+	if !fInfo.IsDir() {
+		return errors.New("http server static path(" + dir + ") is not a directory")
+	}
+
+	return server.AddStaticFS(prefix, os.DirFS(dir), config...)
+}
+
+// AddStaticFS 挂载静态文件系统，可用于 embed.FS，子目录可通过 fs.Sub 获取
+func (server *Server) AddStaticFS(prefix string, fsys fs.FS, config ...StaticConfig) error {
+	if !strings.HasPrefix(prefix, "/") {
+		return errors.New("http server static prefix(" + prefix + ") must start with '/'")
+	}
+
+	s := &static{
+		prefix: strings.TrimRight(prefix, "/"),
+		fsys:   fsys,
+	}
+	if len(config) > 0 {
+		s.config = config[0]
+	}
+	if s.config.Index == "" {
+		s.config.Index = "index.html"
+	}
+
+	server.statics = append(server.statics, s)
+
+	// 较长的挂载路径优先匹配
+	sort.SliceStable(server.statics, func(i, j int) bool {
+		return len(server.statics[i].prefix) > len(server.statics[j].prefix)
+	})
+
+	return nil
+}
+
+// match 是否匹配挂载路径，返回挂载路径下的相对路径
+func (s *static) match(urlPath string) (string, bool) {
+	if s.prefix == "" {
+		return urlPath, true
+	}
+
+	if urlPath == s.prefix || strings.HasPrefix(urlPath, s.prefix+"/") {
+		return urlPath[len(s.prefix):], true
+	}
+
+	return "", false
+}
+
+// OnRequest 处理请求
+func (s *static) OnRequest(c *Context) {
+	rel, _ := s.match(c.Request.Path())
+	name := strings.TrimPrefix(path.Clean("/"+rel), "/")
+	if name == "" {
+		name = "."
+	}
+
+	f, fInfo, err := s.open(name)
+	if err == nil && fInfo.IsDir() {
+		_ = f.Close()
+		name = path.Join(name, s.config.Index)
+		f, fInfo, err = s.open(name)
+	}
+
+	if err != nil && s.config.Spa && path.Ext(name) == "" {
+		name = s.config.Index
+		f, fInfo, err = s.open(name)
+	}
+
+	if err != nil || fInfo.IsDir() {
+		if f != nil {
+			_ = f.Close()
+		}
+		c.Error(NewStatusError(http.StatusNotFound, ""))
+		return
+	}
+	defer f.Close()
+
+	header := c.Response.ResponseWriter.Header()
+
+	if path.Base(name) == s.config.Index {
+		header.Set("Cache-Control", "no-cache")
+	} else if s.config.MaxAge > 0 {
+		header.Set("Cache-Control", "public, max-age="+strconv.Itoa(int(s.config.MaxAge.Seconds())))
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(name))
+
+	if s.config.Precompressed {
+		header.Add("Vary", "Accept-Encoding")
+
+		acceptEncoding := c.Request.Header("Accept-Encoding", "")
+		for _, encoding := range []struct {
+			name string
+			ext  string
+		}{
+			{"br", ".br"},
+			{"gzip", ".gz"},
+		} {
+			if !acceptsEncoding(acceptEncoding, encoding.name) {
+				continue
+			}
+
+			cf, cfInfo, err := s.open(name + encoding.ext)
+			if err != nil || cfInfo.IsDir() {
+				if cf != nil {
+					_ = cf.Close()
+				}
+				continue
+			}
+			defer cf.Close()
+
+			if contentType == "" {
+				contentType = "application/octet-stream"
+			}
+			header.Set("Content-Type", contentType)
+			header.Set("Content-Encoding", encoding.name)
+			header.Set("ETag", etag(cfInfo))
+
+			s.serve(c, name, cf, cfInfo)
+			return
+		}
+	}
+
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	header.Set("ETag", etag(fInfo))
+
+	s.serve(c, name, f, fInfo)
+}
+
+// open 打开文件
+func (s *static) open(name string) (fs.File, fs.FileInfo, error) {
+	f, err := s.fsys.Open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fInfo, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, nil, err
+	}
+
+	return f, fInfo, nil
+}
+
+// serve 输出文件内容，由 http.ServeContent 处理 Range、If-None-Match、If-Modified-Since
+func (s *static) serve(c *Context, name string, f fs.File, fInfo fs.FileInfo) {
+	content, ok := f.(io.ReadSeeker)
+	if !ok {
+		data, err := io.ReadAll(f)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		content = bytes.NewReader(data)
+	}
+
+	http.ServeContent(c.Response.ResponseWriter, c.Request.Request, name, fInfo.ModTime(), content)
+}
+
+// etag 根据修改时间和大小生成 ETag
+func etag(fInfo fs.FileInfo) string {
+	return "\"" + strconv.FormatInt(fInfo.ModTime().UnixNano(), 36) + "-" + strconv.FormatInt(fInfo.Size(), 36) + "\""
+}
+
+// acceptsEncoding Accept-Encoding 是否接受指定编码
+func acceptsEncoding(acceptEncoding string, encoding string) bool {
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if !strings.EqualFold(strings.TrimSpace(name), encoding) {
+			continue
+		}
+
+		if q, ok := strings.CutPrefix(strings.ReplaceAll(params, " ", ""), "q="); ok {
+			if v, err := strconv.ParseFloat(q, 64); err == nil && v == 0 {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
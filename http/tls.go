@@ -0,0 +1,176 @@
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// newTlsConfig 根据配置创建 TLS 配置
+func (server *Server) newTlsConfig() (*tls.Config, error) {
+	if server.config.keyFile == "" {
+		return nil, errors.New("http server config parameter(key_file) is not a valid value")
+	}
+
+	loader := &certLoader{
+		certFile: server.config.certFile,
+		keyFile:  server.config.keyFile,
+		reload:   server.config.certReload,
+	}
+	if err := loader.load(); err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:     server.config.tlsMinVersion,
+		GetCertificate: loader.getCertificate,
+	}
+
+	clientAuth, _ := parseClientAuth(server.config.clientAuth)
+	if server.config.clientCaFile != "" {
+		caBytes, err := os.ReadFile(server.config.clientCaFile)
+		if err != nil {
+			return nil, err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caBytes) {
+			return nil, errors.New("http server client ca file(" + server.config.clientCaFile + ") has no valid certificate")
+		}
+		tlsConfig.ClientCAs = pool
+
+		if server.config.clientAuth == "" {
+			clientAuth = tls.RequireAndVerifyClientCert
+		}
+	}
+	tlsConfig.ClientAuth = clientAuth
+
+	return tlsConfig, nil
+}
+
+// redirectToHttps 将 http 请求跳转到 https
+func (server *Server) redirectToHttps(w http.ResponseWriter, r *http.Request) {
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	if server.config.port != 443 {
+		host = net.JoinHostPort(host, strconv.Itoa(server.config.port))
+	}
+
+	http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusMovedPermanently)
+}
+
+// 证书变更检查间隔
+const certCheckInterval = 10 * time.Second
+
+type certLoader struct {
+	certFile string
+	keyFile  string
+
+	// 证书文件变更后是否自动重新加载
+	reload bool
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	modTime   time.Time
+	checkedAt time.Time
+}
+
+// load 从磁盘加载证书
+func (l *certLoader) load() error {
+	cert, err := tls.LoadX509KeyPair(l.certFile, l.keyFile)
+	if err != nil {
+		return err
+	}
+
+	modTime := l.lastModTime()
+
+	l.mu.Lock()
+	l.cert = &cert
+	l.modTime = modTime
+	l.checkedAt = time.Now()
+	l.mu.Unlock()
+
+	return nil
+}
+
+// lastModTime 证书及私钥文件的最后修改时间
+func (l *certLoader) lastModTime() time.Time {
+	var modTime time.Time
+	for _, path := range []string{l.certFile, l.keyFile} {
+		if info, err := os.Stat(path); err == nil && info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+
+	return modTime
+}
+
+// getCertificate 获取证书，启用自动重新加载时定期检查文件是否变更
+func (l *certLoader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	if l.reload {
+		l.mu.RLock()
+		due := time.Since(l.checkedAt) >= certCheckInterval
+		modTime := l.modTime
+		l.mu.RUnlock()
+
+		if due {
+			if l.lastModTime().After(modTime) {
+				// 加载失败时继续使用旧证书
+				_ = l.load()
+			}
+
+			l.mu.Lock()
+			l.checkedAt = time.Now()
+			l.mu.Unlock()
+		}
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.cert, nil
+}
+
+// parseTlsVersion 解析 TLS 版本，如 "1.2"
+func parseTlsVersion(version string) (uint16, bool) {
+	switch strings.TrimPrefix(strings.ToLower(version), "tls") {
+	case "1.0", "10":
+		return tls.VersionTLS10, true
+	case "1.1", "11":
+		return tls.VersionTLS11, true
+	case "1.2", "12":
+		return tls.VersionTLS12, true
+	case "1.3", "13":
+		return tls.VersionTLS13, true
+	}
+
+	return 0, false
+}
+
+// parseClientAuth 解析客户端证书校验方式
+func parseClientAuth(clientAuth string) (tls.ClientAuthType, bool) {
+	switch clientAuth {
+	case "", "none":
+		return tls.NoClientCert, true
+	case "request":
+		return tls.RequestClientCert, true
+	case "require":
+		return tls.RequireAnyClientCert, true
+	case "verify_if_given":
+		return tls.VerifyClientCertIfGiven, true
+	case "require_and_verify":
+		return tls.RequireAndVerifyClientCert, true
+	}
+
+	return tls.NoClientCert, false
+}
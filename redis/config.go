@@ -107,3 +107,8 @@ func SetIniConfig(name string, section *ini.Section) error {
 
 	return nil
 }
+
+// GetConfigs 获取所有配置项
+func GetConfigs() map[string]*Config {
+	return configs
+}
@@ -1,6 +1,7 @@
 package redis
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/go-redis/redis/v8"
@@ -36,3 +37,17 @@ func (d *Driver) Init() error {
 
 	return nil
 }
+
+// Ping 检查连接
+func (d *Driver) Ping(ctx context.Context) error {
+	return d.client.Ping(ctx).Err()
+}
+
+// Close 关闭连接池
+func (d *Driver) Close() error {
+	if d.client == nil {
+		return nil
+	}
+
+	return d.client.Close()
+}
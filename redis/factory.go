@@ -2,12 +2,19 @@ package redis
 
 import (
 	"errors"
+	"sync"
 )
 
 var drivers map[string]*Driver
 
+// drivers 的互斥锁
+var driversMu sync.Mutex
+
 // GetRedis 获取Redis实例
 func GetRedis(name string) (*Driver, error) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+
 	d, ok := drivers[name]
 	if ok {
 		return d, nil
@@ -32,3 +39,43 @@ func GetRedis(name string) (*Driver, error) {
 
 	return nil, errors.New("redis (" + name + ") not found")
 }
+
+// CloseRedis 关闭指定 Redis 实例的连接池
+func CloseRedis(name string) error {
+	driversMu.Lock()
+	d, ok := drivers[name]
+	if !ok {
+		driversMu.Unlock()
+		return nil
+	}
+
+	delete(drivers, name)
+	driversMu.Unlock()
+
+	return d.Close()
+}
+
+// CloseAll 关闭所有 Redis 实例的连接池
+func CloseAll() error {
+	var errs []error
+	for name := range GetDrivers() {
+		if err := CloseRedis(name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// GetDrivers 获取已创建的实例，不会创建新的连接池
+func GetDrivers() map[string]*Driver {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+
+	d := make(map[string]*Driver, len(drivers))
+	for name, driver := range drivers {
+		d[name] = driver
+	}
+
+	return d
+}
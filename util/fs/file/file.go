@@ -75,3 +75,25 @@ func Copy(src string, dst string) error {
 func Move(src string, dst string) error {
 	return os.Rename(src, dst)
 }
+
+// 将数据流保存为文件，自动创建目录
+func Save(dst string, src io.Reader) (int64, error) {
+	err := os.MkdirAll(filepath.Dir(dst), os.ModePerm)
+	if err != nil {
+		return 0, err
+	}
+
+	dstFile, err := os.Create(dst)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := io.Copy(dstFile, src)
+	if err != nil {
+		_ = dstFile.Close()
+		_ = os.Remove(dst)
+		return n, err
+	}
+
+	return n, dstFile.Close()
+}
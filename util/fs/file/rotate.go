@@ -0,0 +1,124 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// RotateWriter 按大小切割的文件写入器，可用于日志输出
+type RotateWriter struct {
+	// 文件路径
+	path string
+
+	// 单个文件最大字节数，0-不切割
+	maxSize int64
+
+	// 保留的历史文件个数，0-不限制
+	maxBackups int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// NewRotateWriter 创建按大小切割的文件写入器
+func NewRotateWriter(path string, maxSize int64, maxBackups int) (*RotateWriter, error) {
+	w := &RotateWriter{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// Write 写入内容，超出大小限制时先切割文件
+func (w *RotateWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		if err := w.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	if w.maxSize > 0 && w.size > 0 && w.size+int64(len(p)) > w.maxSize {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Close 关闭文件
+func (w *RotateWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+// open 以追加模式打开文件
+func (w *RotateWriter) open() error {
+	err := os.MkdirAll(filepath.Dir(w.path), os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	fInfo, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	w.file = f
+	w.size = fInfo.Size()
+	return nil
+}
+
+// rotate 将当前文件重命名为带时间戳的历史文件，并清理超出个数的历史文件
+func (w *RotateWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	w.file = nil
+
+	backup := w.path + "." + time.Now().Format("20060102150405.000000")
+	if err := os.Rename(w.path, backup); err != nil {
+		return err
+	}
+
+	if w.maxBackups > 0 {
+		backups, err := filepath.Glob(w.path + ".*")
+		if err == nil && len(backups) > w.maxBackups {
+			sort.Strings(backups)
+			for _, path := range backups[:len(backups)-w.maxBackups] {
+				_ = os.Remove(path)
+			}
+		}
+	}
+
+	return w.open()
+}